@@ -71,7 +71,8 @@ func main() {
 	e.Use(middleware.GzipWithConfig(middleware.GzipConfig{
 		Level: router.GZipLevel,
 		Skipper: func(c echo.Context) bool {
-			if strings.Contains(c.Request().URL.Path, "docs") {
+			if strings.Contains(c.Request().URL.Path, "docs") || strings.Contains(c.Request().URL.Path, "events") ||
+				strings.Contains(c.Request().URL.Path, "stream") {
 				return true
 			}
 
@@ -102,6 +103,16 @@ func main() {
 	e.Use(router.HttpCacheInMemory(
 		router.CacheCapacity,
 		router.CacheTTLSeconds,
+		func(c echo.Context) bool {
+			if strings.Contains(c.Request().URL.Path, "webhook") || strings.Contains(c.Request().URL.Path, "events") ||
+				strings.Contains(c.Request().URL.Path, "stream") || strings.Contains(c.Request().URL.Path, "status") ||
+				strings.Contains(c.Request().URL.Path, "messages") || strings.Contains(c.Request().URL.Path, "media") ||
+				strings.Contains(c.Request().URL.Path, "polls") {
+				return true
+			}
+
+			return false
+		},
 	))
 
 	// Router RealIP
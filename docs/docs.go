@@ -59,6 +59,42 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/whatsapp/events/ws": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upgrade Connection to WebSocket and Stream Device Events (Message, Receipt, Presence, Connection) as JSON Frames",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Event"
+                ],
+                "summary": "Stream Device Events over WebSocket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma Separated Event Types, All Events When Empty",
+                        "name": "events",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JWT Token When Authorization Header Can Not be Set",
+                        "name": "token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": ""
+                    }
+                }
+            }
+        },
         "/api/v1/whatsapp/group": {
             "get": {
                 "security": [
@@ -120,21 +156,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/whatsapp/logout": {
+        "/api/v1/whatsapp/login/pair": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Make Device Logout from WhatsApp Multi-Device",
+                "description": "Get Pairing Code to be Entered in Phone \"Link with Phone Number\" Screen",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/html"
                 ],
                 "tags": [
                     "WhatsApp Authentication"
                 ],
-                "summary": "Logout Device from WhatsApp Multi-Device",
+                "summary": "Generate Pairing Code for WhatsApp Multi-Device Login",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Phone Number to Pair, Default to Authenticated JID",
+                        "name": "phone",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "html",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "html",
+                        "description": "Change Output Format in HTML or JSON",
+                        "name": "output",
+                        "in": "formData"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": ""
@@ -142,28 +201,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/whatsapp/registered": {
+        "/api/v1/whatsapp/login/stream": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Check WhatsApp Personal ID is Registered",
+                "description": "Stream Every Successive QR Code as Server-Sent Events Until Login is Success, Timeout or Error",
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
                     "WhatsApp Authentication"
                 ],
-                "summary": "Check If WhatsApp Personal ID is Registered",
+                "summary": "Stream QR Codes for WhatsApp Multi-Device Login",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "WhatsApp Personal ID to Check",
-                        "name": "msisdn",
-                        "in": "query",
-                        "required": true
+                        "description": "JWT Token When Authorization Header Can Not be Set",
+                        "name": "token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -173,40 +231,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/whatsapp/send/audio": {
+        "/api/v1/whatsapp/logout": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Send Audio Message to Spesific WhatsApp Personal ID or Group ID",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Make Device Logout from WhatsApp Multi-Device",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "WhatsApp Message"
-                ],
-                "summary": "Send Audio Message",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Destination WhatsApp Personal ID or Group ID",
-                        "name": "msisdn",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Audio File",
-                        "name": "audio",
-                        "in": "formData",
-                        "required": true
-                    }
+                    "WhatsApp Authentication"
                 ],
+                "summary": "Logout Device from WhatsApp Multi-Device",
                 "responses": {
                     "200": {
                         "description": ""
@@ -214,44 +253,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/whatsapp/send/contact": {
-            "post": {
+        "/api/v1/whatsapp/media/{msgid}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Send Contact Message to Spesific WhatsApp Personal ID or Group ID",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Download Media File of Received or Sent Message by Message ID as Attachment with Its Original MIME Type, Redirect to Presigned URL for S3 Media Storage",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
                     "WhatsApp Message"
                 ],
-                "summary": "Send Contact Message",
+                "summary": "Download Received Media",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Destination WhatsApp Personal ID or Group ID",
-                        "name": "msisdn",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Contact Name",
-                        "name": "name",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Contact Phone",
-                        "name": "phone",
-                        "in": "formData",
+                        "description": "Message ID",
+                        "name": "msgid",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -262,38 +284,72 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/whatsapp/send/document": {
-            "post": {
+        "/api/v1/whatsapp/messages": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Send Document Message to Spesific WhatsApp Personal ID or Group ID",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "List Sent and Received Messages of Authenticated Device from Message Archive",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "WhatsApp Message"
                 ],
-                "summary": "Send Document Message",
+                "summary": "List Archived Messages",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Destination WhatsApp Personal ID or Group ID",
-                        "name": "msisdn",
-                        "in": "formData",
-                        "required": true
+                        "description": "Chat ID (MSISDN or Group ID) to Filter",
+                        "name": "chat",
+                        "in": "query"
                     },
                     {
-                        "type": "file",
-                        "description": "Document File",
-                        "name": "document",
-                        "in": "formData",
-                        "required": true
+                        "enum": [
+                            "text",
+                            "image",
+                            "video",
+                            "audio",
+                            "document",
+                            "sticker",
+                            "location",
+                            "live_location",
+                            "contact",
+                            "reaction",
+                            "poll",
+                            "poll_vote"
+                        ],
+                        "type": "string",
+                        "description": "Message Type to Filter",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum Message Timestamp (Unix Seconds)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum Message Timestamp (Unix Seconds)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum Messages per Page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Next Page Cursor from Previous Response",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -303,52 +359,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/whatsapp/send/image": {
-            "post": {
+        "/api/v1/whatsapp/messages/{msgid}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Send Image Message to Spesific WhatsApp Personal ID or Group ID",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Revoke (Delete for Everyone) Message in WhatsApp Personal ID or Group ID, Group Admin Can Revoke Message Sent by Others",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "WhatsApp Message"
                 ],
-                "summary": "Send Image Message",
+                "summary": "Revoke Sent Message",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Destination WhatsApp Personal ID or Group ID",
-                        "name": "msisdn",
-                        "in": "formData",
+                        "description": "Message ID",
+                        "name": "msgid",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Caption Image Message",
-                        "name": "caption",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Image File",
-                        "name": "image",
-                        "in": "formData",
+                        "description": "WhatsApp Personal ID or Group ID of The Message",
+                        "name": "msisdn",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "type": "boolean",
-                        "default": false,
-                        "description": "Is View Once",
-                        "name": "viewonce",
-                        "in": "formData"
+                        "type": "string",
+                        "description": "Message Sender, Required for Group Admin When Message is Not Archived",
+                        "name": "sender",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -356,16 +401,14 @@ const docTemplate = `{
                         "description": ""
                     }
                 }
-            }
-        },
-        "/api/v1/whatsapp/send/link": {
-            "post": {
+            },
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Send Link Message to Spesific WhatsApp Personal ID or Group ID",
+                "description": "Edit Text Message Sent by This Device Within WhatsApp Edit Window, Previous Text is Kept as Edit History",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -375,25 +418,19 @@ const docTemplate = `{
                 "tags": [
                     "WhatsApp Message"
                 ],
-                "summary": "Send Link Message",
+                "summary": "Edit Sent Text Message",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Destination WhatsApp Personal ID or Group ID",
-                        "name": "msisdn",
-                        "in": "formData",
+                        "description": "Message ID",
+                        "name": "msgid",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Link Caption",
-                        "name": "caption",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Link URL",
-                        "name": "url",
+                        "description": "New Text Message",
+                        "name": "message",
                         "in": "formData",
                         "required": true
                     }
@@ -405,44 +442,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/whatsapp/send/location": {
-            "post": {
+        "/api/v1/whatsapp/messages/{msgid}/status": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Send Location Message to Spesific WhatsApp Personal ID or Group ID",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Get Server Acknowledgement, Delivered, Read and Played Status per Recipient of Sent Message, Group Members Without Receipt are Pending",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "WhatsApp Message"
                 ],
-                "summary": "Send Location Message",
+                "summary": "Get Sent Message Delivery Status",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Destination WhatsApp Personal ID or Group ID",
-                        "name": "msisdn",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "number",
-                        "description": "Location Latitude",
-                        "name": "latitude",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "number",
-                        "description": "Location Longitude",
-                        "name": "longitude",
-                        "in": "formData",
+                        "description": "Message ID",
+                        "name": "msgid",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -453,37 +473,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/whatsapp/send/sticker": {
-            "post": {
+        "/api/v1/whatsapp/polls/{msgid}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Send Sticker Message to Spesific WhatsApp Personal ID or Group ID",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Get Aggregated Votes per Option with Voters of Sent or Received Poll Message",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "WhatsApp Message"
                 ],
-                "summary": "Send Sticker Message",
+                "summary": "Get Poll Results",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Destination WhatsApp Personal ID or Group ID",
-                        "name": "msisdn",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Sticker File",
-                        "name": "sticker",
-                        "in": "formData",
+                        "description": "Poll Message ID",
+                        "name": "msgid",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -494,37 +504,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/whatsapp/send/text": {
-            "post": {
+        "/api/v1/whatsapp/registered": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Send Text Message to Spesific WhatsApp Personal ID or Group ID",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Check WhatsApp Personal ID is Registered",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "WhatsApp Message"
+                    "WhatsApp Authentication"
                 ],
-                "summary": "Send Text Message",
+                "summary": "Check If WhatsApp Personal ID is Registered",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Destination WhatsApp Personal ID or Group ID",
+                        "description": "WhatsApp Personal ID to Check",
                         "name": "msisdn",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Text Message",
-                        "name": "message",
-                        "in": "formData",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -535,16 +535,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/whatsapp/send/video": {
+        "/api/v1/whatsapp/send/audio": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Send Video Message to Spesific WhatsApp Personal ID or Group ID",
+                "description": "Send Audio Message to Spesific WhatsApp Personal ID or Group ID, Also Accept JSON Body with \"msisdn\" and Media \"url\" or \"base64\" Plus Optional \"mimetype\" and \"filename\"",
                 "consumes": [
-                    "multipart/form-data"
+                    "multipart/form-data",
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
@@ -552,7 +553,7 @@ const docTemplate = `{
                 "tags": [
                     "WhatsApp Message"
                 ],
-                "summary": "Send Video Message",
+                "summary": "Send Audio Message",
                 "parameters": [
                     {
                         "type": "string",
@@ -562,7 +563,880 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
+                        "type": "file",
+                        "description": "Audio File",
+                        "name": "audio",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Is Voice Note (PTT), Audio is Converted to OGG/Opus When Needed",
+                        "name": "ptt",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message ID",
+                        "name": "quoted_msgid",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message Sender, Required for Group When Message is Not Archived",
+                        "name": "quoted_sender",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message Body, Required When Message is Not Archived",
+                        "name": "quoted_body",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/api/v1/whatsapp/send/contact": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send Contact Message to Spesific WhatsApp Personal ID or Group ID",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Message"
+                ],
+                "summary": "Send Contact Message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Destination WhatsApp Personal ID or Group ID",
+                        "name": "msisdn",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contact Name",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contact Phone",
+                        "name": "phone",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message ID",
+                        "name": "quoted_msgid",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message Sender, Required for Group When Message is Not Archived",
+                        "name": "quoted_sender",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message Body, Required When Message is Not Archived",
+                        "name": "quoted_body",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/api/v1/whatsapp/send/document": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send Document Message to Spesific WhatsApp Personal ID or Group ID, Also Accept JSON Body with \"msisdn\" and Media \"url\" or \"base64\" Plus Optional \"mimetype\" and \"filename\"",
+                "consumes": [
+                    "multipart/form-data",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Message"
+                ],
+                "summary": "Send Document Message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Destination WhatsApp Personal ID or Group ID",
+                        "name": "msisdn",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Document File, PDF is Sent with Page Count and First Page Thumbnail",
+                        "name": "document",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caption Document Message",
+                        "name": "caption",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma Separated MSISDN to Mention, Also Detected from @MSISDN in Group Message Text",
+                        "name": "mentions",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message ID",
+                        "name": "quoted_msgid",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message Sender, Required for Group When Message is Not Archived",
+                        "name": "quoted_sender",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message Body, Required When Message is Not Archived",
+                        "name": "quoted_body",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/api/v1/whatsapp/send/gif": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send Looping GIF Message from Animated GIF or Short MP4 Video to Spesific WhatsApp Personal ID or Group ID, Also Accept JSON Body with \"msisdn\" and Media \"url\" or \"base64\" Plus Optional \"mimetype\" and \"filename\"",
+                "consumes": [
+                    "multipart/form-data",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Message"
+                ],
+                "summary": "Send GIF Message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Destination WhatsApp Personal ID or Group ID",
+                        "name": "msisdn",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caption GIF Message",
+                        "name": "caption",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Animated GIF or MP4 Video File up to 30 Seconds, GIF is Converted to MP4 and Audio is Removed",
+                        "name": "gif",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "none",
+                            "giphy",
+                            "tenor"
+                        ],
+                        "type": "string",
+                        "default": "none",
+                        "description": "GIF Attribution",
+                        "name": "attribution",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma Separated MSISDN to Mention, Also Detected from @MSISDN in Group Message Text",
+                        "name": "mentions",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message ID",
+                        "name": "quoted_msgid",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message Sender, Required for Group When Message is Not Archived",
+                        "name": "quoted_sender",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message Body, Required When Message is Not Archived",
+                        "name": "quoted_body",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/api/v1/whatsapp/send/image": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send Image Message to Spesific WhatsApp Personal ID or Group ID, Also Accept JSON Body with \"msisdn\" and Media \"url\" or \"base64\" Plus Optional \"mimetype\" and \"filename\"",
+                "consumes": [
+                    "multipart/form-data",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Message"
+                ],
+                "summary": "Send Image Message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Destination WhatsApp Personal ID or Group ID",
+                        "name": "msisdn",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caption Image Message",
+                        "name": "caption",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image File",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Is View Once",
+                        "name": "viewonce",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma Separated MSISDN to Mention, Also Detected from @MSISDN in Group Message Text",
+                        "name": "mentions",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message ID",
+                        "name": "quoted_msgid",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message Sender, Required for Group When Message is Not Archived",
+                        "name": "quoted_sender",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message Body, Required When Message is Not Archived",
+                        "name": "quoted_body",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/api/v1/whatsapp/send/link": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send Link Message to Spesific WhatsApp Personal ID or Group ID",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Message"
+                ],
+                "summary": "Send Link Message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Destination WhatsApp Personal ID or Group ID",
+                        "name": "msisdn",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link Caption",
+                        "name": "caption",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link URL",
+                        "name": "url",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message ID",
+                        "name": "quoted_msgid",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message Sender, Required for Group When Message is Not Archived",
+                        "name": "quoted_sender",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message Body, Required When Message is Not Archived",
+                        "name": "quoted_body",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/api/v1/whatsapp/send/live-location": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send Live Location Message to Spesific WhatsApp Personal ID or Group ID, Send Again with \"msgid\" to Update Coordinates of The Same Live Location Message Until Duration Ends",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Message"
+                ],
+                "summary": "Send or Update Live Location Message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Destination WhatsApp Personal ID or Group ID, Required When Starting Live Location",
+                        "name": "msisdn",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Live Location Message ID to Update",
+                        "name": "msgid",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Location Latitude",
+                        "name": "latitude",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Location Longitude",
+                        "name": "longitude",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Live Location Caption",
+                        "name": "caption",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Live Location Duration in Seconds, Between 60 and 28800 (Default 900)",
+                        "name": "duration",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message ID",
+                        "name": "quoted_msgid",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message Sender, Required for Group When Message is Not Archived",
+                        "name": "quoted_sender",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message Body, Required When Message is Not Archived",
+                        "name": "quoted_body",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/api/v1/whatsapp/send/location": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send Location Message to Spesific WhatsApp Personal ID or Group ID",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Message"
+                ],
+                "summary": "Send Location Message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Destination WhatsApp Personal ID or Group ID",
+                        "name": "msisdn",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Location Latitude",
+                        "name": "latitude",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Location Longitude",
+                        "name": "longitude",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Location Name",
+                        "name": "name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Location Address",
+                        "name": "address",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Location URL",
+                        "name": "url",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Location Static Map Image, Converted to JPEG Thumbnail",
+                        "name": "thumbnail",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message ID",
+                        "name": "quoted_msgid",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message Sender, Required for Group When Message is Not Archived",
+                        "name": "quoted_sender",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message Body, Required When Message is Not Archived",
+                        "name": "quoted_body",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/api/v1/whatsapp/send/poll": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send Poll Message to Spesific WhatsApp Personal ID or Group ID",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Message"
+                ],
+                "summary": "Send Poll Message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Destination WhatsApp Personal ID or Group ID",
+                        "name": "msisdn",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Poll Question",
+                        "name": "question",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Poll Options, Repeat Field for Each Option (2 to 12 Options)",
+                        "name": "options",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of Selectable Options, 0 for Any Number of Options (Default 1)",
+                        "name": "selectable",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message ID",
+                        "name": "quoted_msgid",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message Sender, Required for Group When Message is Not Archived",
+                        "name": "quoted_sender",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message Body, Required When Message is Not Archived",
+                        "name": "quoted_body",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/api/v1/whatsapp/send/reaction": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send Reaction to Spesific Message in WhatsApp Personal ID or Group ID",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Message"
+                ],
+                "summary": "Send Reaction Message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Destination WhatsApp Personal ID or Group ID",
+                        "name": "msisdn",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reacted Message ID",
+                        "name": "msgid",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reacted Message Sender, Required for Group When Message is Not Archived",
+                        "name": "sender",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reaction Emoji, Empty to Remove Reaction",
+                        "name": "emoji",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/api/v1/whatsapp/send/sticker": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send Sticker Message to Spesific WhatsApp Personal ID or Group ID, Also Accept JSON Body with \"msisdn\" and Media \"url\" or \"base64\" Plus Optional \"mimetype\" and \"filename\"",
+                "consumes": [
+                    "multipart/form-data",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Message"
+                ],
+                "summary": "Send Sticker Message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Destination WhatsApp Personal ID or Group ID",
+                        "name": "msisdn",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Sticker File, Animated WebP is Sent As Is",
+                        "name": "sticker",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sticker Pack Name, Default from WHATSAPP_MEDIA_STICKER_PACK_NAME",
+                        "name": "pack_name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sticker Pack Publisher, Default from WHATSAPP_MEDIA_STICKER_PACK_PUBLISHER",
+                        "name": "pack_publisher",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message ID",
+                        "name": "quoted_msgid",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message Sender, Required for Group When Message is Not Archived",
+                        "name": "quoted_sender",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message Body, Required When Message is Not Archived",
+                        "name": "quoted_body",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/api/v1/whatsapp/send/text": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send Text Message to Spesific WhatsApp Personal ID or Group ID",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Message"
+                ],
+                "summary": "Send Text Message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Destination WhatsApp Personal ID or Group ID",
+                        "name": "msisdn",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Text Message",
+                        "name": "message",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma Separated MSISDN to Mention, Also Detected from @MSISDN in Group Message Text",
+                        "name": "mentions",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message ID",
+                        "name": "quoted_msgid",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message Sender, Required for Group When Message is Not Archived",
+                        "name": "quoted_sender",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message Body, Required When Message is Not Archived",
+                        "name": "quoted_body",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/api/v1/whatsapp/send/video": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send Video Message to Spesific WhatsApp Personal ID or Group ID, Also Accept JSON Body with \"msisdn\" and Media \"url\" or \"base64\" Plus Optional \"mimetype\" and \"filename\"",
+                "consumes": [
+                    "multipart/form-data",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Message"
+                ],
+                "summary": "Send Video Message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Destination WhatsApp Personal ID or Group ID",
+                        "name": "msisdn",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
                         "description": "Caption Video Message",
                         "name": "caption",
                         "in": "formData",
@@ -581,6 +1455,221 @@ const docTemplate = `{
                         "description": "Is View Once",
                         "name": "viewonce",
                         "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma Separated MSISDN to Mention, Also Detected from @MSISDN in Group Message Text",
+                        "name": "mentions",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message ID",
+                        "name": "quoted_msgid",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message Sender, Required for Group When Message is Not Archived",
+                        "name": "quoted_sender",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reply to Message Body, Required When Message is Not Archived",
+                        "name": "quoted_body",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/api/v1/whatsapp/status": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get Connection and Login Status of Authenticated Device",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Authentication"
+                ],
+                "summary": "Get WhatsApp Client Connection Status",
+                "responses": {
+                    "200": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/api/v1/whatsapp/webhook": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get Webhook Configuration for Authenticated Device, Secret is Not Returned",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Webhook"
+                ],
+                "summary": "Get Webhook Configuration",
+                "responses": {
+                    "200": {
+                        "description": ""
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set Webhook Callback URL, Signing Secret and Subscribed Event Types for Authenticated Device, Secret is Only Returned Here",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Webhook"
+                ],
+                "summary": "Set Webhook Configuration",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook Callback URL",
+                        "name": "url",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook Signing Secret, Generated When Empty",
+                        "name": "secret",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma Separated Subscribed Event Types, All Events When Empty",
+                        "name": "events",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": ""
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete Webhook Configuration for Authenticated Device",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Webhook"
+                ],
+                "summary": "Delete Webhook Configuration",
+                "responses": {
+                    "200": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/api/v1/whatsapp/webhook/dead": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List Webhook Deliveries That Reached Maximum Attempts for Authenticated Device",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Webhook"
+                ],
+                "summary": "List Dead-Letter Webhook Deliveries",
+                "responses": {
+                    "200": {
+                        "description": ""
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete Dead-Letter Webhook Deliveries for Authenticated Device",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Webhook"
+                ],
+                "summary": "Purge Dead-Letter Webhook Deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delivery ID, All Deliveries When Empty",
+                        "name": "id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/api/v1/whatsapp/webhook/dead/replay": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue Dead-Letter Webhook Deliveries Again for Authenticated Device",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WhatsApp Webhook"
+                ],
+                "summary": "Replay Dead-Letter Webhook Deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delivery ID, All Deliveries When Empty",
+                        "name": "id",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
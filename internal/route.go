@@ -35,19 +35,47 @@ func Routes(e *echo.Echo) {
 		SigningKey: []byte(auth.AuthJWTSecret),
 	}
 
+	authJWTStreamConfig := authJWTConfig
+	authJWTStreamConfig.TokenLookup = "header:" + echo.HeaderAuthorization + ",query:token"
+
 	e.POST(router.BaseURL+"/login", ctlWhatsApp.Login, middleware.JWTWithConfig(authJWTConfig))
+	e.POST(router.BaseURL+"/login/pair", ctlWhatsApp.LoginPair, middleware.JWTWithConfig(authJWTConfig))
+	e.GET(router.BaseURL+"/login/stream", ctlWhatsApp.LoginStream, middleware.JWTWithConfig(authJWTStreamConfig))
 	e.GET(router.BaseURL+"/registered", ctlWhatsApp.Registered, middleware.JWTWithConfig(authJWTConfig))
+	e.GET(router.BaseURL+"/status", ctlWhatsApp.Status, middleware.JWTWithConfig(authJWTConfig))
 	e.POST(router.BaseURL+"/logout", ctlWhatsApp.Logout, middleware.JWTWithConfig(authJWTConfig))
 
 	e.GET(router.BaseURL+"/group", ctlWhatsApp.GetGroup, middleware.JWTWithConfig(authJWTConfig))
 
 	e.POST(router.BaseURL+"/send/text", ctlWhatsApp.SendText, middleware.JWTWithConfig(authJWTConfig))
 	e.POST(router.BaseURL+"/send/location", ctlWhatsApp.SendLocation, middleware.JWTWithConfig(authJWTConfig))
+	e.POST(router.BaseURL+"/send/live-location", ctlWhatsApp.SendLiveLocation, middleware.JWTWithConfig(authJWTConfig))
 	e.POST(router.BaseURL+"/send/contact", ctlWhatsApp.SendContact, middleware.JWTWithConfig(authJWTConfig))
 	e.POST(router.BaseURL+"/send/link", ctlWhatsApp.SendLink, middleware.JWTWithConfig(authJWTConfig))
 	e.POST(router.BaseURL+"/send/document", ctlWhatsApp.SendDocument, middleware.JWTWithConfig(authJWTConfig))
 	e.POST(router.BaseURL+"/send/image", ctlWhatsApp.SendImage, middleware.JWTWithConfig(authJWTConfig))
 	e.POST(router.BaseURL+"/send/audio", ctlWhatsApp.SendAudio, middleware.JWTWithConfig(authJWTConfig))
 	e.POST(router.BaseURL+"/send/video", ctlWhatsApp.SendVideo, middleware.JWTWithConfig(authJWTConfig))
+	e.POST(router.BaseURL+"/send/gif", ctlWhatsApp.SendGIF, middleware.JWTWithConfig(authJWTConfig))
 	e.POST(router.BaseURL+"/send/sticker", ctlWhatsApp.SendSticker, middleware.JWTWithConfig(authJWTConfig))
+	e.POST(router.BaseURL+"/send/poll", ctlWhatsApp.SendPoll, middleware.JWTWithConfig(authJWTConfig))
+	e.POST(router.BaseURL+"/send/reaction", ctlWhatsApp.SendReaction, middleware.JWTWithConfig(authJWTConfig))
+
+	e.GET(router.BaseURL+"/messages", ctlWhatsApp.GetMessages, middleware.JWTWithConfig(authJWTConfig))
+	e.GET(router.BaseURL+"/messages/:msgid/status", ctlWhatsApp.GetMessageStatus, middleware.JWTWithConfig(authJWTConfig))
+	e.DELETE(router.BaseURL+"/messages/:msgid", ctlWhatsApp.RevokeMessage, middleware.JWTWithConfig(authJWTConfig))
+	e.PATCH(router.BaseURL+"/messages/:msgid", ctlWhatsApp.EditMessage, middleware.JWTWithConfig(authJWTConfig))
+
+	e.GET(router.BaseURL+"/media/:msgid", ctlWhatsApp.GetMedia, middleware.JWTWithConfig(authJWTConfig))
+
+	e.GET(router.BaseURL+"/polls/:msgid", ctlWhatsApp.GetPoll, middleware.JWTWithConfig(authJWTConfig))
+
+	e.GET(router.BaseURL+"/webhook", ctlWhatsApp.GetWebhook, middleware.JWTWithConfig(authJWTConfig))
+	e.PUT(router.BaseURL+"/webhook", ctlWhatsApp.SetWebhook, middleware.JWTWithConfig(authJWTConfig))
+	e.DELETE(router.BaseURL+"/webhook", ctlWhatsApp.DeleteWebhook, middleware.JWTWithConfig(authJWTConfig))
+	e.GET(router.BaseURL+"/webhook/dead", ctlWhatsApp.GetWebhookDead, middleware.JWTWithConfig(authJWTConfig))
+	e.POST(router.BaseURL+"/webhook/dead/replay", ctlWhatsApp.ReplayWebhookDead, middleware.JWTWithConfig(authJWTConfig))
+	e.DELETE(router.BaseURL+"/webhook/dead", ctlWhatsApp.PurgeWebhookDead, middleware.JWTWithConfig(authJWTConfig))
+
+	e.GET(router.BaseURL+"/events/ws", ctlWhatsApp.EventStream, middleware.JWTWithConfig(authJWTStreamConfig))
 }
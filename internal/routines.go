@@ -12,9 +12,14 @@ func Routines(cron *cron.Cron) {
 
 	cron.AddFunc("0 * * * * *", func() {
 		// If WhatsAppClient Connection is more than 0
-		if len(pkgWhatsApp.WhatsAppClient) > 0 {
+		if pkgWhatsApp.WhatsAppClient.Len() > 0 {
 			// Check Every Authenticated MSISDN
-			for jid, client := range pkgWhatsApp.WhatsAppClient {
+			for jid, client := range pkgWhatsApp.WhatsAppClient.List() {
+				// Skip Device That is Not Paired Yet
+				if client.Store.ID == nil {
+					continue
+				}
+
 				// Get Real JID from Datastore
 				realJID := client.Store.ID.User
 
@@ -31,11 +36,26 @@ func Routines(cron *cron.Cron) {
 
 					// Logout WhatsAppClient Device
 					_ = pkgWhatsApp.WhatsAppLogout(jid)
-					delete(pkgWhatsApp.WhatsAppClient, jid)
+					pkgWhatsApp.WhatsAppClient.Remove(jid)
 				}
 			}
 		}
 	})
 
+	cron.AddFunc("*/5 * * * * *", func() {
+		// Retry Pending Webhook Deliveries in Outbox
+		pkgWhatsApp.WhatsAppWebhookOutboxProcess()
+	})
+
+	cron.AddFunc("*/30 * * * * *", func() {
+		// Stop Live Location Sharing That Has Reached Its Duration
+		pkgWhatsApp.WhatsAppLiveLocationExpire()
+	})
+
+	cron.AddFunc("0 0 * * * *", func() {
+		// Remove Downloaded Media Older Than Retention Period
+		pkgWhatsApp.WhatsAppMediaCleanup()
+	})
+
 	cron.Start()
 }
@@ -4,16 +4,56 @@ type RequestLogin struct {
 	Output string
 }
 
+type RequestLoginPair struct {
+	Phone  string
+	Output string
+}
+
 type RequestSendMessage struct {
-	RJID     string
-	Message  string
-	ViewOnce bool
+	RJID          string
+	Message       string
+	Caption       string
+	ViewOnce      bool
+	PTT           bool
+	Attribution   string
+	PackName      string
+	PackPublisher string
+}
+
+type RequestSendMedia struct {
+	RJID          string   `json:"msisdn"`
+	URL           string   `json:"url"`
+	Base64        string   `json:"base64"`
+	MimeType      string   `json:"mimetype"`
+	FileName      string   `json:"filename"`
+	Caption       string   `json:"caption"`
+	ViewOnce      bool     `json:"viewonce"`
+	PTT           bool     `json:"ptt"`
+	Attribution   string   `json:"attribution"`
+	PackName      string   `json:"pack_name"`
+	PackPublisher string   `json:"pack_publisher"`
+	Mentions      []string `json:"mentions"`
+	QuotedMsgID   string   `json:"quoted_msgid"`
+	QuotedSender  string   `json:"quoted_sender"`
+	QuotedBody    string   `json:"quoted_body"`
 }
 
 type RequestSendLocation struct {
 	RJID      string
 	Latitude  float64
 	Longitude float64
+	Name      string
+	Address   string
+	URL       string
+}
+
+type RequestSendLiveLocation struct {
+	RJID      string
+	MsgID     string
+	Latitude  float64
+	Longitude float64
+	Caption   string
+	Duration  int
 }
 
 type RequestSendContact struct {
@@ -27,3 +67,23 @@ type RequestSendLink struct {
 	Caption string
 	URL     string
 }
+
+type RequestSendPoll struct {
+	RJID       string
+	Question   string
+	Options    []string
+	Selectable int
+}
+
+type RequestSendReaction struct {
+	RJID   string
+	MsgID  string
+	Sender string
+	Emoji  string
+}
+
+type RequestWebhook struct {
+	URL    string
+	Secret string
+	Events []string
+}
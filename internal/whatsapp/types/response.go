@@ -5,6 +5,30 @@ type ResponseLogin struct {
 	Timeout int    `json:"timeout"`
 }
 
+type ResponseLoginPair struct {
+	PairCode string `json:"paircode"`
+	Timeout  int    `json:"timeout"`
+}
+
+type ResponseLoginStream struct {
+	QRCode  string `json:"qrcode,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Timeout int    `json:"timeout,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
 type ResponseSendMessage struct {
 	MsgID string `json:"msgid"`
 }
+
+type ResponseWebhook struct {
+	URL       string   `json:"url"`
+	Secret    string   `json:"secret,omitempty"`
+	HasSecret bool     `json:"has_secret"`
+	Events    []string `json:"events"`
+	UpdatedAt int64    `json:"updated_at"`
+}
+
+type ResponseWebhookDead struct {
+	Affected int64 `json:"affected"`
+}
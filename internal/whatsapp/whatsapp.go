@@ -2,14 +2,23 @@ package whatsapp
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"mime"
 	"mime/multipart"
+	"net/http"
+	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt"
+	"github.com/gorilla/websocket"
 	"github.com/labstack/echo/v4"
 
+	"github.com/dimaskiddo/go-whatsapp-multidevice-rest/pkg/log"
 	"github.com/dimaskiddo/go-whatsapp-multidevice-rest/pkg/router"
 	pkgWhatsApp "github.com/dimaskiddo/go-whatsapp-multidevice-rest/pkg/whatsapp"
 
@@ -17,6 +26,22 @@ import (
 	typWhatsApp "github.com/dimaskiddo/go-whatsapp-multidevice-rest/internal/whatsapp/types"
 )
 
+const (
+	wsWriteWait  = 10 * time.Second
+	wsPongWait   = 60 * time.Second
+	wsPingPeriod = (wsPongWait * 9) / 10
+)
+
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin: func(r *http.Request) bool {
+		// Allow Origin Based on CORS Configuration
+		origin := r.Header.Get("Origin")
+		return router.CORSOrigin == "*" || len(origin) == 0 || origin == router.CORSOrigin
+	},
+}
+
 func jwtPayload(c echo.Context) typAuth.AuthJWTClaimsPayload {
 	jwtToken := c.Get("user").(*jwt.Token)
 	jwtClaims := jwtToken.Claims.(*typAuth.AuthJWTClaims)
@@ -24,6 +49,23 @@ func jwtPayload(c echo.Context) typAuth.AuthJWTClaimsPayload {
 	return jwtClaims.Data
 }
 
+func writeEventStream(c echo.Context, event string, data interface{}) error {
+	// Encode Event Data to JSON
+	eventData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	// Write Server-Sent Event and Flush it Immediately
+	_, err = fmt.Fprintf(c.Response(), "event: %s\ndata: %s\n\n", event, eventData)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Flush()
+	return nil
+}
+
 func convertFileToBytes(file multipart.File) ([]byte, error) {
 	// Create Empty Buffer
 	buffer := bytes.NewBuffer(nil)
@@ -101,6 +143,154 @@ func Login(c echo.Context) error {
 	return router.ResponseSuccessWithData(c, "Successfully Generated QR Code", resLogin)
 }
 
+// LoginPair
+// @Summary     Generate Pairing Code for WhatsApp Multi-Device Login
+// @Description Get Pairing Code to be Entered in Phone "Link with Phone Number" Screen
+// @Tags        WhatsApp Authentication
+// @Accept      multipart/form-data
+// @Produce     json
+// @Produce     html
+// @Param       phone     formData  string  false  "Phone Number to Pair, Default to Authenticated JID"
+// @Param       output    formData  string  false  "Change Output Format in HTML or JSON"  Enums(html, json)  default(html)
+// @Success     200
+// @Security    BearerAuth
+// @Router      /api/v1/whatsapp/login/pair [post]
+func LoginPair(c echo.Context) error {
+	var err error
+	jid := jwtPayload(c).JID
+
+	var reqLoginPair typWhatsApp.RequestLoginPair
+	reqLoginPair.Phone = strings.TrimSpace(c.FormValue("phone"))
+	reqLoginPair.Output = strings.TrimSpace(c.FormValue("output"))
+
+	if len(reqLoginPair.Phone) == 0 {
+		reqLoginPair.Phone = jid
+	}
+
+	if len(reqLoginPair.Output) == 0 {
+		reqLoginPair.Output = "html"
+	}
+
+	// Initialize WhatsApp Client
+	pkgWhatsApp.WhatsAppInitClient(nil, jid)
+
+	// Get WhatsApp Pairing Code
+	pairCode, pairCodeTimeout, err := pkgWhatsApp.WhatsAppLoginPair(jid, reqLoginPair.Phone)
+	if err != nil {
+		return router.ResponseInternalError(c, err.Error())
+	}
+
+	// If Return is Not Pairing Code But Reconnected
+	// Then Return OK With Reconnected Status
+	if pairCode == "WhatsApp Client is Reconnected" {
+		return router.ResponseSuccess(c, pairCode)
+	}
+
+	var resLoginPair typWhatsApp.ResponseLoginPair
+	resLoginPair.PairCode = pairCode
+	resLoginPair.Timeout = pairCodeTimeout
+
+	if reqLoginPair.Output == "html" {
+		htmlContent := `
+    <html>
+      <head>
+        <title>WhatsApp Multi-Device Login</title>
+        <meta name="viewport" content="width=device-width, initial-scale=1, shrink-to-fit=no" />
+      </head>
+      <body>
+        <h1>` + resLoginPair.PairCode + `</h1>
+        <p>
+          <b>Pairing Code</b>
+          <br/>
+          Timeout in ` + strconv.Itoa(resLoginPair.Timeout) + ` Second(s)
+        </p>
+      </body>
+    </html>`
+
+		return router.ResponseSuccessWithHTML(c, htmlContent)
+	}
+
+	return router.ResponseSuccessWithData(c, "Successfully Generated Pairing Code", resLoginPair)
+}
+
+// LoginStream
+// @Summary     Stream QR Codes for WhatsApp Multi-Device Login
+// @Description Stream Every Successive QR Code as Server-Sent Events Until Login is Success, Timeout or Error
+// @Tags        WhatsApp Authentication
+// @Produce     text/event-stream
+// @Param       token     query  string  false "JWT Token When Authorization Header Can Not be Set"
+// @Success     200
+// @Security    BearerAuth
+// @Router      /api/v1/whatsapp/login/stream [get]
+func LoginStream(c echo.Context) error {
+	var err error
+	jid := jwtPayload(c).JID
+
+	// Initialize WhatsApp Client
+	pkgWhatsApp.WhatsAppInitClient(nil, jid)
+
+	// Get WhatsApp QR Code Channel
+	// Login is Cancelled When The Stream is Closed by Client
+	qrChan, err := pkgWhatsApp.WhatsAppLoginStream(c.Request().Context(), jid)
+	if err != nil {
+		return router.ResponseInternalError(c, err.Error())
+	}
+
+	// Set Server-Sent Events Response Header
+	c.Response().Header().Set(echo.HeaderContentType, "text/event-stream")
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-cache")
+	c.Response().Header().Set(echo.HeaderConnection, "keep-alive")
+	c.Response().Header().Set("X-Accel-Buffering", "no")
+	c.Response().WriteHeader(http.StatusOK)
+
+	// If QR Code Channel is Empty Then Client is Reconnected
+	if qrChan == nil {
+		return writeEventStream(c, "success", typWhatsApp.ResponseLoginStream{Message: "WhatsApp Client is Reconnected"})
+	}
+
+	// Stream Every QR Code Until Channel is Closed
+	for evt := range qrChan {
+		var resLoginStream typWhatsApp.ResponseLoginStream
+
+		switch evt.Event {
+		case "code":
+			qrImage, errEncode := pkgWhatsApp.WhatsAppEncodeQR(evt.Code)
+			if errEncode != nil {
+				resLoginStream.Message = errEncode.Error()
+				err = writeEventStream(c, "error", resLoginStream)
+				break
+			}
+
+			resLoginStream.QRCode = "data:image/png;base64," + qrImage
+			resLoginStream.Code = evt.Code
+			resLoginStream.Timeout = int(evt.Timeout.Seconds())
+			err = writeEventStream(c, "code", resLoginStream)
+
+		case "success":
+			resLoginStream.Message = "WhatsApp Client is Logged In"
+			err = writeEventStream(c, "success", resLoginStream)
+
+		case "timeout":
+			resLoginStream.Message = "WhatsApp QR Code is Timeout"
+			err = writeEventStream(c, "timeout", resLoginStream)
+
+		default:
+			resLoginStream.Message = evt.Event
+			if evt.Error != nil {
+				resLoginStream.Message = evt.Error.Error()
+			}
+			err = writeEventStream(c, "error", resLoginStream)
+		}
+
+		// Stop Streaming When Client is Gone
+		if err != nil {
+			return nil
+		}
+	}
+
+	return nil
+}
+
 // Registered
 // @Summary     Check If WhatsApp Personal ID is Registered
 // @Description Check WhatsApp Personal ID is Registered
@@ -126,6 +316,22 @@ func Registered(c echo.Context) error {
 	return router.ResponseSuccess(c, "WhatsApp Personal ID is Registered")
 }
 
+// Status
+// @Summary     Get WhatsApp Client Connection Status
+// @Description Get Connection and Login Status of Authenticated Device
+// @Tags        WhatsApp Authentication
+// @Produce     json
+// @Success     200
+// @Security    BearerAuth
+// @Router      /api/v1/whatsapp/status [get]
+func Status(c echo.Context) error {
+	jid := jwtPayload(c).JID
+
+	status := pkgWhatsApp.WhatsAppStatusGet(jid)
+
+	return router.ResponseSuccessWithData(c, "Successfully Get WhatsApp Client Status", status)
+}
+
 // Logout
 // @Summary     Logout Device from WhatsApp Multi-Device
 // @Description Make Device Logout from WhatsApp Multi-Device
@@ -154,6 +360,10 @@ func Logout(c echo.Context) error {
 // @Produce     json
 // @Param       msisdn    formData  string  true  "Destination WhatsApp Personal ID or Group ID"
 // @Param       message   formData  string  true  "Text Message"
+// @Param       mentions      formData  string  false "Comma Separated MSISDN to Mention, Also Detected from @MSISDN in Group Message Text"
+// @Param       quoted_msgid  formData  string  false "Reply to Message ID"
+// @Param       quoted_sender formData  string  false "Reply to Message Sender, Required for Group When Message is Not Archived"
+// @Param       quoted_body   formData  string  false "Reply to Message Body, Required When Message is Not Archived"
 // @Success     200
 // @Security    BearerAuth
 // @Router      /api/v1/whatsapp/send/text [post]
@@ -174,7 +384,7 @@ func SendText(c echo.Context) error {
 	}
 
 	var resSendMessage typWhatsApp.ResponseSendMessage
-	resSendMessage.MsgID, err = pkgWhatsApp.WhatsAppSendText(c.Request().Context(), jid, reqSendMessage.RJID, reqSendMessage.Message)
+	resSendMessage.MsgID, err = pkgWhatsApp.WhatsAppSendText(c.Request().Context(), jid, reqSendMessage.RJID, reqSendMessage.Message, sendOptions(c))
 	if err != nil {
 		return router.ResponseInternalError(c, err.Error())
 	}
@@ -191,6 +401,13 @@ func SendText(c echo.Context) error {
 // @Param       msisdn    formData  string  true  "Destination WhatsApp Personal ID or Group ID"
 // @Param       latitude  formData  number  true  "Location Latitude"
 // @Param       longitude formData  number  true  "Location Longitude"
+// @Param       name      formData  string  false "Location Name"
+// @Param       address   formData  string  false "Location Address"
+// @Param       url       formData  string  false "Location URL"
+// @Param       thumbnail formData  file    false "Location Static Map Image, Converted to JPEG Thumbnail"
+// @Param       quoted_msgid  formData  string  false "Reply to Message ID"
+// @Param       quoted_sender formData  string  false "Reply to Message Sender, Required for Group When Message is Not Archived"
+// @Param       quoted_body   formData  string  false "Reply to Message Body, Required When Message is Not Archived"
 // @Success     200
 // @Security    BearerAuth
 // @Router      /api/v1/whatsapp/send/location [post]
@@ -215,8 +432,33 @@ func SendLocation(c echo.Context) error {
 		return router.ResponseBadRequest(c, "Missing Form Value MSISDN")
 	}
 
+	reqSendLocation.Name = strings.TrimSpace(c.FormValue("name"))
+	reqSendLocation.Address = strings.TrimSpace(c.FormValue("address"))
+	reqSendLocation.URL = strings.TrimSpace(c.FormValue("url"))
+
+	locationDetail := pkgWhatsApp.LocationDetail{
+		Name:    reqSendLocation.Name,
+		Address: reqSendLocation.Address,
+		URL:     reqSendLocation.URL,
+	}
+
+	// Static Map Thumbnail is Optional
+	fileStream, _, err := c.Request().FormFile("thumbnail")
+	if err != nil && !errors.Is(err, http.ErrMissingFile) {
+		return router.ResponseBadRequest(c, err.Error())
+	}
+
+	if fileStream != nil {
+		defer fileStream.Close()
+
+		locationDetail.Thumbnail, err = convertFileToBytes(fileStream)
+		if err != nil {
+			return router.ResponseInternalError(c, err.Error())
+		}
+	}
+
 	var resSendMessage typWhatsApp.ResponseSendMessage
-	resSendMessage.MsgID, err = pkgWhatsApp.WhatsAppSendLocation(c.Request().Context(), jid, reqSendLocation.RJID, reqSendLocation.Latitude, reqSendLocation.Longitude)
+	resSendMessage.MsgID, err = pkgWhatsApp.WhatsAppSendLocation(c.Request().Context(), jid, reqSendLocation.RJID, reqSendLocation.Latitude, reqSendLocation.Longitude, locationDetail, sendOptions(c))
 	if err != nil {
 		return router.ResponseInternalError(c, err.Error())
 	}
@@ -224,6 +466,84 @@ func SendLocation(c echo.Context) error {
 	return router.ResponseSuccessWithData(c, "Successfully Send Location Message", resSendMessage)
 }
 
+// SendLiveLocation
+// @Summary     Send or Update Live Location Message
+// @Description Send Live Location Message to Spesific WhatsApp Personal ID or Group ID, Send Again with "msgid" to Update Coordinates of The Same Live Location Message Until Duration Ends
+// @Tags        WhatsApp Message
+// @Accept      multipart/form-data
+// @Produce     json
+// @Param       msisdn    formData  string  false "Destination WhatsApp Personal ID or Group ID, Required When Starting Live Location"
+// @Param       msgid     formData  string  false "Live Location Message ID to Update"
+// @Param       latitude  formData  number  true  "Location Latitude"
+// @Param       longitude formData  number  true  "Location Longitude"
+// @Param       caption   formData  string  false "Live Location Caption"
+// @Param       duration  formData  int     false "Live Location Duration in Seconds, Between 60 and 28800 (Default 900)"
+// @Param       quoted_msgid  formData  string  false "Reply to Message ID"
+// @Param       quoted_sender formData  string  false "Reply to Message Sender, Required for Group When Message is Not Archived"
+// @Param       quoted_body   formData  string  false "Reply to Message Body, Required When Message is Not Archived"
+// @Success     200
+// @Security    BearerAuth
+// @Router      /api/v1/whatsapp/send/live-location [post]
+func SendLiveLocation(c echo.Context) error {
+	var err error
+	jid := jwtPayload(c).JID
+
+	var reqSendLiveLocation typWhatsApp.RequestSendLiveLocation
+	reqSendLiveLocation.RJID = strings.TrimSpace(c.FormValue("msisdn"))
+	reqSendLiveLocation.MsgID = strings.TrimSpace(c.FormValue("msgid"))
+	reqSendLiveLocation.Caption = strings.TrimSpace(c.FormValue("caption"))
+
+	reqSendLiveLocation.Latitude, err = strconv.ParseFloat(strings.TrimSpace(c.FormValue("latitude")), 64)
+	if err != nil {
+		return router.ResponseBadRequest(c, "Error While Decoding Latitude to Float64")
+	}
+
+	reqSendLiveLocation.Longitude, err = strconv.ParseFloat(strings.TrimSpace(c.FormValue("longitude")), 64)
+	if err != nil {
+		return router.ResponseBadRequest(c, "Error While Decoding Longitude to Float64")
+	}
+
+	var resSendMessage typWhatsApp.ResponseSendMessage
+
+	// Update Coordinates When Message ID is Provided
+	if len(reqSendLiveLocation.MsgID) > 0 {
+		resSendMessage.MsgID, err = pkgWhatsApp.WhatsAppUpdateLiveLocation(c.Request().Context(), jid, reqSendLiveLocation.MsgID, reqSendLiveLocation.Latitude, reqSendLiveLocation.Longitude)
+		if err != nil {
+			switch {
+			case errors.Is(err, pkgWhatsApp.ErrLiveLocationNotFound):
+				return router.ResponseNotFound(c, err.Error())
+			case errors.Is(err, pkgWhatsApp.ErrLiveLocationExpired):
+				return router.ResponseBadRequest(c, err.Error())
+			}
+
+			return router.ResponseInternalError(c, err.Error())
+		}
+
+		return router.ResponseSuccessWithData(c, "Successfully Update Live Location Message", resSendMessage)
+	}
+
+	if len(reqSendLiveLocation.RJID) == 0 {
+		return router.ResponseBadRequest(c, "Missing Form Value MSISDN")
+	}
+
+	reqSendLiveLocation.Duration = pkgWhatsApp.WhatsAppLiveLocationDurationDefault
+
+	duration := strings.TrimSpace(c.FormValue("duration"))
+	if len(duration) > 0 {
+		reqSendLiveLocation.Duration, err = strconv.Atoi(duration)
+		if err != nil {
+			return router.ResponseBadRequest(c, "Error While Decoding Duration to Int")
+		}
+	}
+
+	resSendMessage.MsgID, err = pkgWhatsApp.WhatsAppSendLiveLocation(c.Request().Context(), jid, reqSendLiveLocation.RJID, reqSendLiveLocation.Latitude, reqSendLiveLocation.Longitude, reqSendLiveLocation.Caption, reqSendLiveLocation.Duration, sendOptions(c))
+	if err != nil {
+		return router.ResponseInternalError(c, err.Error())
+	}
+
+	return router.ResponseSuccessWithData(c, "Successfully Send Live Location Message", resSendMessage)
+}
+
 // SendContact
 // @Summary     Send Contact Message
 // @Description Send Contact Message to Spesific WhatsApp Personal ID or Group ID
@@ -233,6 +553,9 @@ func SendLocation(c echo.Context) error {
 // @Param       msisdn    formData  string  true  "Destination WhatsApp Personal ID or Group ID"
 // @Param       name      formData  string  true  "Contact Name"
 // @Param       phone     formData  string  true  "Contact Phone"
+// @Param       quoted_msgid  formData  string  false "Reply to Message ID"
+// @Param       quoted_sender formData  string  false "Reply to Message Sender, Required for Group When Message is Not Archived"
+// @Param       quoted_body   formData  string  false "Reply to Message Body, Required When Message is Not Archived"
 // @Success     200
 // @Security    BearerAuth
 // @Router      /api/v1/whatsapp/send/contact [post]
@@ -258,7 +581,7 @@ func SendContact(c echo.Context) error {
 	}
 
 	var resSendMessage typWhatsApp.ResponseSendMessage
-	resSendMessage.MsgID, err = pkgWhatsApp.WhatsAppSendContact(c.Request().Context(), jid, reqSendContact.RJID, reqSendContact.Name, reqSendContact.Phone)
+	resSendMessage.MsgID, err = pkgWhatsApp.WhatsAppSendContact(c.Request().Context(), jid, reqSendContact.RJID, reqSendContact.Name, reqSendContact.Phone, sendOptions(c))
 	if err != nil {
 		return router.ResponseInternalError(c, err.Error())
 	}
@@ -275,6 +598,9 @@ func SendContact(c echo.Context) error {
 // @Param       msisdn    formData  string  true  "Destination WhatsApp Personal ID or Group ID"
 // @Param       caption   formData  string  false "Link Caption"
 // @Param       url       formData  string  true  "Link URL"
+// @Param       quoted_msgid  formData  string  false "Reply to Message ID"
+// @Param       quoted_sender formData  string  false "Reply to Message Sender, Required for Group When Message is Not Archived"
+// @Param       quoted_body   formData  string  false "Reply to Message Body, Required When Message is Not Archived"
 // @Success     200
 // @Security    BearerAuth
 // @Router      /api/v1/whatsapp/send/link [post]
@@ -296,7 +622,7 @@ func SendLink(c echo.Context) error {
 	}
 
 	var resSendMessage typWhatsApp.ResponseSendMessage
-	resSendMessage.MsgID, err = pkgWhatsApp.WhatsAppSendLink(c.Request().Context(), jid, reqSendLink.RJID, reqSendLink.Caption, reqSendLink.URL)
+	resSendMessage.MsgID, err = pkgWhatsApp.WhatsAppSendLink(c.Request().Context(), jid, reqSendLink.RJID, reqSendLink.Caption, reqSendLink.URL, sendOptions(c))
 	if err != nil {
 		return router.ResponseInternalError(c, err.Error())
 	}
@@ -306,12 +632,18 @@ func SendLink(c echo.Context) error {
 
 // SendDocument
 // @Summary     Send Document Message
-// @Description Send Document Message to Spesific WhatsApp Personal ID or Group ID
+// @Description Send Document Message to Spesific WhatsApp Personal ID or Group ID, Also Accept JSON Body with "msisdn" and Media "url" or "base64" Plus Optional "mimetype" and "filename"
 // @Tags        WhatsApp Message
 // @Accept      multipart/form-data
+// @Accept      json
 // @Produce     json
 // @Param       msisdn    formData  string  true  "Destination WhatsApp Personal ID or Group ID"
-// @Param       document  formData  file    true  "Document File"
+// @Param       document  formData  file    true  "Document File, PDF is Sent with Page Count and First Page Thumbnail"
+// @Param       caption   formData  string  false "Caption Document Message"
+// @Param       mentions      formData  string  false "Comma Separated MSISDN to Mention, Also Detected from @MSISDN in Group Message Text"
+// @Param       quoted_msgid  formData  string  false "Reply to Message ID"
+// @Param       quoted_sender formData  string  false "Reply to Message Sender, Required for Group When Message is Not Archived"
+// @Param       quoted_body   formData  string  false "Reply to Message Body, Required When Message is Not Archived"
 // @Success     200
 // @Security    BearerAuth
 // @Router      /api/v1/whatsapp/send/document [post]
@@ -321,14 +653,19 @@ func SendDocument(c echo.Context) error {
 
 // SendImage
 // @Summary     Send Image Message
-// @Description Send Image Message to Spesific WhatsApp Personal ID or Group ID
+// @Description Send Image Message to Spesific WhatsApp Personal ID or Group ID, Also Accept JSON Body with "msisdn" and Media "url" or "base64" Plus Optional "mimetype" and "filename"
 // @Tags        WhatsApp Message
 // @Accept      multipart/form-data
+// @Accept      json
 // @Produce     json
 // @Param       msisdn    formData  string  true  "Destination WhatsApp Personal ID or Group ID"
 // @Param       caption   formData  string  true  "Caption Image Message"
 // @Param       image     formData  file    true  "Image File"
 // @Param       viewonce  formData  bool    false "Is View Once"              default(false)
+// @Param       mentions      formData  string  false "Comma Separated MSISDN to Mention, Also Detected from @MSISDN in Group Message Text"
+// @Param       quoted_msgid  formData  string  false "Reply to Message ID"
+// @Param       quoted_sender formData  string  false "Reply to Message Sender, Required for Group When Message is Not Archived"
+// @Param       quoted_body   formData  string  false "Reply to Message Body, Required When Message is Not Archived"
 // @Success     200
 // @Security    BearerAuth
 // @Router      /api/v1/whatsapp/send/image [post]
@@ -338,12 +675,17 @@ func SendImage(c echo.Context) error {
 
 // SendAudio
 // @Summary     Send Audio Message
-// @Description Send Audio Message to Spesific WhatsApp Personal ID or Group ID
+// @Description Send Audio Message to Spesific WhatsApp Personal ID or Group ID, Also Accept JSON Body with "msisdn" and Media "url" or "base64" Plus Optional "mimetype" and "filename"
 // @Tags        WhatsApp Message
 // @Accept      multipart/form-data
+// @Accept      json
 // @Produce     json
 // @Param       msisdn    formData  string  true  "Destination WhatsApp Personal ID or Group ID"
 // @Param       audio     formData  file    true  "Audio File"
+// @Param       ptt       formData  bool    false "Is Voice Note (PTT), Audio is Converted to OGG/Opus When Needed"  default(false)
+// @Param       quoted_msgid  formData  string  false "Reply to Message ID"
+// @Param       quoted_sender formData  string  false "Reply to Message Sender, Required for Group When Message is Not Archived"
+// @Param       quoted_body   formData  string  false "Reply to Message Body, Required When Message is Not Archived"
 // @Success     200
 // @Security    BearerAuth
 // @Router      /api/v1/whatsapp/send/audio [post]
@@ -353,14 +695,19 @@ func SendAudio(c echo.Context) error {
 
 // SendVideo
 // @Summary     Send Video Message
-// @Description Send Video Message to Spesific WhatsApp Personal ID or Group ID
+// @Description Send Video Message to Spesific WhatsApp Personal ID or Group ID, Also Accept JSON Body with "msisdn" and Media "url" or "base64" Plus Optional "mimetype" and "filename"
 // @Tags        WhatsApp Message
 // @Accept      multipart/form-data
+// @Accept      json
 // @Produce     json
 // @Param       msisdn    formData  string  true  "Destination WhatsApp Personal ID or Group ID"
 // @Param       caption   formData  string  true  "Caption Video Message"
 // @Param       video     formData  file    true  "Video File"
 // @Param       viewonce  formData  bool    false "Is View Once"              default(false)
+// @Param       mentions      formData  string  false "Comma Separated MSISDN to Mention, Also Detected from @MSISDN in Group Message Text"
+// @Param       quoted_msgid  formData  string  false "Reply to Message ID"
+// @Param       quoted_sender formData  string  false "Reply to Message Sender, Required for Group When Message is Not Archived"
+// @Param       quoted_body   formData  string  false "Reply to Message Body, Required When Message is Not Archived"
 // @Success     200
 // @Security    BearerAuth
 // @Router      /api/v1/whatsapp/send/video [post]
@@ -368,14 +715,42 @@ func SendVideo(c echo.Context) error {
 	return sendMedia(c, "video")
 }
 
+// SendGIF
+// @Summary     Send GIF Message
+// @Description Send Looping GIF Message from Animated GIF or Short MP4 Video to Spesific WhatsApp Personal ID or Group ID, Also Accept JSON Body with "msisdn" and Media "url" or "base64" Plus Optional "mimetype" and "filename"
+// @Tags        WhatsApp Message
+// @Accept      multipart/form-data
+// @Accept      json
+// @Produce     json
+// @Param       msisdn      formData  string  true  "Destination WhatsApp Personal ID or Group ID"
+// @Param       caption     formData  string  false "Caption GIF Message"
+// @Param       gif         formData  file    true  "Animated GIF or MP4 Video File up to 30 Seconds, GIF is Converted to MP4 and Audio is Removed"
+// @Param       attribution formData  string  false "GIF Attribution"  Enums(none, giphy, tenor) default(none)
+// @Param       mentions      formData  string  false "Comma Separated MSISDN to Mention, Also Detected from @MSISDN in Group Message Text"
+// @Param       quoted_msgid  formData  string  false "Reply to Message ID"
+// @Param       quoted_sender formData  string  false "Reply to Message Sender, Required for Group When Message is Not Archived"
+// @Param       quoted_body   formData  string  false "Reply to Message Body, Required When Message is Not Archived"
+// @Success     200
+// @Security    BearerAuth
+// @Router      /api/v1/whatsapp/send/gif [post]
+func SendGIF(c echo.Context) error {
+	return sendMedia(c, "gif")
+}
+
 // SendSticker
 // @Summary     Send Sticker Message
-// @Description Send Sticker Message to Spesific WhatsApp Personal ID or Group ID
+// @Description Send Sticker Message to Spesific WhatsApp Personal ID or Group ID, Also Accept JSON Body with "msisdn" and Media "url" or "base64" Plus Optional "mimetype" and "filename"
 // @Tags        WhatsApp Message
 // @Accept      multipart/form-data
+// @Accept      json
 // @Produce     json
 // @Param       msisdn    formData  string  true  "Destination WhatsApp Personal ID or Group ID"
-// @Param       sticker   formData  file    true  "Sticker File"
+// @Param       sticker   formData  file    true  "Sticker File, Animated WebP is Sent As Is"
+// @Param       pack_name      formData  string  false "Sticker Pack Name, Default from WHATSAPP_MEDIA_STICKER_PACK_NAME"
+// @Param       pack_publisher formData  string  false "Sticker Pack Publisher, Default from WHATSAPP_MEDIA_STICKER_PACK_PUBLISHER"
+// @Param       quoted_msgid  formData  string  false "Reply to Message ID"
+// @Param       quoted_sender formData  string  false "Reply to Message Sender, Required for Group When Message is Not Archived"
+// @Param       quoted_body   formData  string  false "Reply to Message Body, Required When Message is Not Archived"
 // @Success     200
 // @Security    BearerAuth
 // @Router      /api/v1/whatsapp/send/sticker [post]
@@ -383,78 +758,286 @@ func SendSticker(c echo.Context) error {
 	return sendMedia(c, "sticker")
 }
 
-func sendMedia(c echo.Context, mediaType string) error {
+// SendPoll
+// @Summary     Send Poll Message
+// @Description Send Poll Message to Spesific WhatsApp Personal ID or Group ID
+// @Tags        WhatsApp Message
+// @Accept      multipart/form-data
+// @Produce     json
+// @Param       msisdn     formData  string    true  "Destination WhatsApp Personal ID or Group ID"
+// @Param       question   formData  string    true  "Poll Question"
+// @Param       options    formData  []string  true  "Poll Options, Repeat Field for Each Option (2 to 12 Options)" collectionFormat(multi)
+// @Param       selectable formData  int       false "Number of Selectable Options, 0 for Any Number of Options (Default 1)"
+// @Param       quoted_msgid  formData  string  false "Reply to Message ID"
+// @Param       quoted_sender formData  string  false "Reply to Message Sender, Required for Group When Message is Not Archived"
+// @Param       quoted_body   formData  string  false "Reply to Message Body, Required When Message is Not Archived"
+// @Success     200
+// @Security    BearerAuth
+// @Router      /api/v1/whatsapp/send/poll [post]
+func SendPoll(c echo.Context) error {
 	var err error
 	jid := jwtPayload(c).JID
 
-	var reqSendMessage typWhatsApp.RequestSendMessage
-	reqSendMessage.RJID = strings.TrimSpace(c.FormValue("msisdn"))
+	var reqSendPoll typWhatsApp.RequestSendPoll
+	reqSendPoll.RJID = strings.TrimSpace(c.FormValue("msisdn"))
+	reqSendPoll.Question = strings.TrimSpace(c.FormValue("question"))
 
-	// Read Uploaded File Based on Send Media Type
-	var fileStream multipart.File
-	var fileHeader *multipart.FileHeader
+	formParams, err := c.FormParams()
+	if err != nil {
+		return router.ResponseBadRequest(c, err.Error())
+	}
 
-	switch mediaType {
-	case "document":
-		fileStream, fileHeader, err = c.Request().FormFile("document")
-		reqSendMessage.Message = fileHeader.Filename
+	for _, option := range formParams["options"] {
+		option = strings.TrimSpace(option)
+		if len(option) > 0 {
+			reqSendPoll.Options = append(reqSendPoll.Options, option)
+		}
+	}
 
-	case "image":
-		fileStream, fileHeader, err = c.Request().FormFile("image")
-		reqSendMessage.Message = strings.TrimSpace(c.FormValue("caption"))
+	reqSendPoll.Selectable = 1
+	if len(strings.TrimSpace(c.FormValue("selectable"))) > 0 {
+		reqSendPoll.Selectable, err = strconv.Atoi(strings.TrimSpace(c.FormValue("selectable")))
+		if err != nil {
+			return router.ResponseBadRequest(c, "Invalid Form Value Selectable")
+		}
+	}
 
-	case "audio":
-		fileStream, fileHeader, err = c.Request().FormFile("audio")
+	if len(reqSendPoll.RJID) == 0 {
+		return router.ResponseBadRequest(c, "Missing Form Value MSISDN")
+	}
 
-	case "video":
-		fileStream, fileHeader, err = c.Request().FormFile("video")
-		reqSendMessage.Message = strings.TrimSpace(c.FormValue("caption"))
+	if len(reqSendPoll.Question) == 0 {
+		return router.ResponseBadRequest(c, "Missing Form Value Question")
+	}
 
-	case "sticker":
-		fileStream, fileHeader, err = c.Request().FormFile("sticker")
+	if len(reqSendPoll.Options) == 0 {
+		return router.ResponseBadRequest(c, "Missing Form Value Options")
+	}
+
+	var resSendMessage typWhatsApp.ResponseSendMessage
+	resSendMessage.MsgID, err = pkgWhatsApp.WhatsAppSendPoll(c.Request().Context(), jid, reqSendPoll.RJID, reqSendPoll.Question, reqSendPoll.Options, reqSendPoll.Selectable, sendOptions(c))
+	if err != nil {
+		return router.ResponseInternalError(c, err.Error())
 	}
 
-	// Don't Forget to Close The File Stream
-	defer fileStream.Close()
+	return router.ResponseSuccessWithData(c, "Successfully Send Poll Message", resSendMessage)
+}
+
+// SendReaction
+// @Summary     Send Reaction Message
+// @Description Send Reaction to Spesific Message in WhatsApp Personal ID or Group ID
+// @Tags        WhatsApp Message
+// @Accept      multipart/form-data
+// @Produce     json
+// @Param       msisdn    formData  string  true  "Destination WhatsApp Personal ID or Group ID"
+// @Param       msgid     formData  string  true  "Reacted Message ID"
+// @Param       sender    formData  string  false "Reacted Message Sender, Required for Group When Message is Not Archived"
+// @Param       emoji     formData  string  false "Reaction Emoji, Empty to Remove Reaction"
+// @Success     200
+// @Security    BearerAuth
+// @Router      /api/v1/whatsapp/send/reaction [post]
+func SendReaction(c echo.Context) error {
+	var err error
+	jid := jwtPayload(c).JID
+
+	var reqSendReaction typWhatsApp.RequestSendReaction
+	reqSendReaction.RJID = strings.TrimSpace(c.FormValue("msisdn"))
+	reqSendReaction.MsgID = strings.TrimSpace(c.FormValue("msgid"))
+	reqSendReaction.Sender = strings.TrimSpace(c.FormValue("sender"))
+	reqSendReaction.Emoji = strings.TrimSpace(c.FormValue("emoji"))
+
+	if len(reqSendReaction.RJID) == 0 {
+		return router.ResponseBadRequest(c, "Missing Form Value MSISDN")
+	}
 
-	// Get Uploaded File MIME Type
-	fileType := fileHeader.Header.Get("Content-Type")
+	if len(reqSendReaction.MsgID) == 0 {
+		return router.ResponseBadRequest(c, "Missing Form Value Message ID")
+	}
 
-	// If There are Some Errors While Opeening The File Stream
-	// Return Bad Request with Original Error Message
+	var resSendMessage typWhatsApp.ResponseSendMessage
+	resSendMessage.MsgID, err = pkgWhatsApp.WhatsAppSendReaction(c.Request().Context(), jid, reqSendReaction.RJID, reqSendReaction.MsgID, reqSendReaction.Sender, reqSendReaction.Emoji)
 	if err != nil {
-		return router.ResponseBadRequest(c, err.Error())
+		return router.ResponseInternalError(c, err.Error())
 	}
 
-	// Make Sure RJID is Filled
-	if len(reqSendMessage.RJID) == 0 {
-		return router.ResponseBadRequest(c, "Missing Form Value MSISDN")
+	return router.ResponseSuccessWithData(c, "Successfully Send Reaction Message", resSendMessage)
+}
+
+func sendOptions(c echo.Context) pkgWhatsApp.SendOptions {
+	var mentions []string
+	for _, mention := range strings.Split(c.FormValue("mentions"), ",") {
+		mention = strings.TrimSpace(mention)
+		if len(mention) > 0 {
+			mentions = append(mentions, mention)
+		}
 	}
 
-	// Check if Media Type is "image" or "video"
-	// Then Parse ViewOnce Parameter
-	if mediaType == "image" || mediaType == "video" {
-		isViewOnce := strings.TrimSpace(c.FormValue("viewonce"))
+	return pkgWhatsApp.SendOptions{
+		QuotedID:     strings.TrimSpace(c.FormValue("quoted_msgid")),
+		QuotedSender: strings.TrimSpace(c.FormValue("quoted_sender")),
+		QuotedBody:   strings.TrimSpace(c.FormValue("quoted_body")),
+		Mentions:     mentions,
+	}
+}
+
+func sendMedia(c echo.Context, mediaType string) error {
+	var err error
+	jid := jwtPayload(c).JID
+
+	var reqSendMessage typWhatsApp.RequestSendMessage
+	var msgOptions pkgWhatsApp.SendOptions
+	var fileBytes []byte
+	var fileType string
+
+	if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		// Read Media from JSON Body as URL or Base64 Content
+		var reqSendMedia typWhatsApp.RequestSendMedia
+
+		err = json.NewDecoder(c.Request().Body).Decode(&reqSendMedia)
+		if err != nil {
+			return router.ResponseBadRequest(c, "Error While Decoding JSON Body")
+		}
+
+		reqSendMessage.RJID = strings.TrimSpace(reqSendMedia.RJID)
+		reqSendMessage.ViewOnce = reqSendMedia.ViewOnce
+		reqSendMessage.PTT = reqSendMedia.PTT
+		reqSendMessage.Attribution = strings.TrimSpace(reqSendMedia.Attribution)
+		reqSendMessage.PackName = strings.TrimSpace(reqSendMedia.PackName)
+		reqSendMessage.PackPublisher = strings.TrimSpace(reqSendMedia.PackPublisher)
+
+		if len(reqSendMessage.RJID) == 0 {
+			return router.ResponseBadRequest(c, "Missing JSON Value MSISDN")
+		}
+
+		var media pkgWhatsApp.MediaFetched
+
+		switch {
+		case len(reqSendMedia.URL) > 0:
+			media, err = pkgWhatsApp.WhatsAppMediaFetch(c.Request().Context(), strings.TrimSpace(reqSendMedia.URL))
+			if err != nil {
+				return router.ResponseBadRequest(c, err.Error())
+			}
 
-		if len(isViewOnce) == 0 {
-			// If ViewOnce Parameter Doesn't Exist or Empty String
-			// Then Set it Default to False
-			reqSendMessage.ViewOnce = false
-		} else {
-			// If ViewOnce Parameter is not Empty
-			// Then Parse it to Bool
-			reqSendMessage.ViewOnce, err = strconv.ParseBool(isViewOnce)
+		case len(reqSendMedia.Base64) > 0:
+			media, err = pkgWhatsApp.WhatsAppMediaDecodeBase64(reqSendMedia.Base64)
 			if err != nil {
 				return router.ResponseBadRequest(c, err.Error())
 			}
+
+		default:
+			return router.ResponseBadRequest(c, "Missing JSON Value URL or Base64")
 		}
-	}
 
-	// Convert File Stream in to Bytes
-	// Since WhatsApp Proto for Media is only Accepting Bytes format
-	fileBytes, err := convertFileToBytes(fileStream)
-	if err != nil {
-		return router.ResponseInternalError(c, err.Error())
+		// Explicit MIME Type and File Name Take Precedence
+		fileBytes = media.Bytes
+		fileType = media.MimeType
+		if len(strings.TrimSpace(reqSendMedia.MimeType)) > 0 {
+			fileType = strings.TrimSpace(reqSendMedia.MimeType)
+		}
+
+		switch mediaType {
+		case "document":
+			reqSendMessage.Message = media.FileName
+			if len(strings.TrimSpace(reqSendMedia.FileName)) > 0 {
+				reqSendMessage.Message = strings.TrimSpace(reqSendMedia.FileName)
+			}
+
+			if len(reqSendMessage.Message) == 0 {
+				return router.ResponseBadRequest(c, "Missing JSON Value Filename")
+			}
+
+			reqSendMessage.Caption = strings.TrimSpace(reqSendMedia.Caption)
+
+		case "image", "video", "gif":
+			reqSendMessage.Message = strings.TrimSpace(reqSendMedia.Caption)
+		}
+
+		msgOptions = pkgWhatsApp.SendOptions{
+			QuotedID:     strings.TrimSpace(reqSendMedia.QuotedMsgID),
+			QuotedSender: strings.TrimSpace(reqSendMedia.QuotedSender),
+			QuotedBody:   strings.TrimSpace(reqSendMedia.QuotedBody),
+			Mentions:     reqSendMedia.Mentions,
+		}
+	} else {
+		// Read Uploaded File Based on Send Media Type
+		reqSendMessage.RJID = strings.TrimSpace(c.FormValue("msisdn"))
+
+		fileStream, fileHeader, err := c.Request().FormFile(mediaType)
+
+		// If There are Some Errors While Opeening The File Stream
+		// Return Bad Request with Original Error Message
+		if err != nil {
+			return router.ResponseBadRequest(c, err.Error())
+		}
+
+		// Don't Forget to Close The File Stream
+		defer fileStream.Close()
+
+		// Get Uploaded File MIME Type
+		fileType = fileHeader.Header.Get("Content-Type")
+
+		switch mediaType {
+		case "document":
+			reqSendMessage.Message = fileHeader.Filename
+			reqSendMessage.Caption = strings.TrimSpace(c.FormValue("caption"))
+
+		case "image", "video":
+			reqSendMessage.Message = strings.TrimSpace(c.FormValue("caption"))
+
+		case "gif":
+			reqSendMessage.Message = strings.TrimSpace(c.FormValue("caption"))
+			reqSendMessage.Attribution = strings.TrimSpace(c.FormValue("attribution"))
+
+		case "sticker":
+			reqSendMessage.PackName = strings.TrimSpace(c.FormValue("pack_name"))
+			reqSendMessage.PackPublisher = strings.TrimSpace(c.FormValue("pack_publisher"))
+		}
+
+		// Make Sure RJID is Filled
+		if len(reqSendMessage.RJID) == 0 {
+			return router.ResponseBadRequest(c, "Missing Form Value MSISDN")
+		}
+
+		// Check if Media Type is "image" or "video"
+		// Then Parse ViewOnce Parameter
+		if mediaType == "image" || mediaType == "video" {
+			isViewOnce := strings.TrimSpace(c.FormValue("viewonce"))
+
+			if len(isViewOnce) == 0 {
+				// If ViewOnce Parameter Doesn't Exist or Empty String
+				// Then Set it Default to False
+				reqSendMessage.ViewOnce = false
+			} else {
+				// If ViewOnce Parameter is not Empty
+				// Then Parse it to Bool
+				reqSendMessage.ViewOnce, err = strconv.ParseBool(isViewOnce)
+				if err != nil {
+					return router.ResponseBadRequest(c, err.Error())
+				}
+			}
+		}
+
+		// Check if Media Type is "audio"
+		// Then Parse PTT (Voice Note) Parameter
+		if mediaType == "audio" {
+			isPTT := strings.TrimSpace(c.FormValue("ptt"))
+
+			if len(isPTT) > 0 {
+				reqSendMessage.PTT, err = strconv.ParseBool(isPTT)
+				if err != nil {
+					return router.ResponseBadRequest(c, err.Error())
+				}
+			}
+		}
+
+		// Convert File Stream in to Bytes
+		// Since WhatsApp Proto for Media is only Accepting Bytes format
+		fileBytes, err = convertFileToBytes(fileStream)
+		if err != nil {
+			return router.ResponseInternalError(c, err.Error())
+		}
+
+		msgOptions = sendOptions(c)
 	}
 
 	// Send Media Message Based on Media Type
@@ -462,19 +1045,22 @@ func sendMedia(c echo.Context, mediaType string) error {
 	var resSendMessage typWhatsApp.ResponseSendMessage
 	switch mediaType {
 	case "document":
-		resSendMessage.MsgID, err = pkgWhatsApp.WhatsAppSendDocument(ctx, jid, reqSendMessage.RJID, fileBytes, fileType, reqSendMessage.Message)
+		resSendMessage.MsgID, err = pkgWhatsApp.WhatsAppSendDocument(ctx, jid, reqSendMessage.RJID, fileBytes, fileType, reqSendMessage.Message, reqSendMessage.Caption, msgOptions)
 
 	case "image":
-		resSendMessage.MsgID, err = pkgWhatsApp.WhatsAppSendImage(ctx, jid, reqSendMessage.RJID, fileBytes, fileType, reqSendMessage.Message, reqSendMessage.ViewOnce)
+		resSendMessage.MsgID, err = pkgWhatsApp.WhatsAppSendImage(ctx, jid, reqSendMessage.RJID, fileBytes, fileType, reqSendMessage.Message, reqSendMessage.ViewOnce, msgOptions)
 
 	case "audio":
-		resSendMessage.MsgID, err = pkgWhatsApp.WhatsAppSendAudio(ctx, jid, reqSendMessage.RJID, fileBytes, fileType)
+		resSendMessage.MsgID, err = pkgWhatsApp.WhatsAppSendAudio(ctx, jid, reqSendMessage.RJID, fileBytes, fileType, reqSendMessage.PTT, msgOptions)
 
 	case "video":
-		resSendMessage.MsgID, err = pkgWhatsApp.WhatsAppSendVideo(ctx, jid, reqSendMessage.RJID, fileBytes, fileType, reqSendMessage.Message, reqSendMessage.ViewOnce)
+		resSendMessage.MsgID, err = pkgWhatsApp.WhatsAppSendVideo(ctx, jid, reqSendMessage.RJID, fileBytes, fileType, reqSendMessage.Message, reqSendMessage.ViewOnce, nil, msgOptions)
+
+	case "gif":
+		resSendMessage.MsgID, err = pkgWhatsApp.WhatsAppSendGIF(ctx, jid, reqSendMessage.RJID, fileBytes, fileType, reqSendMessage.Message, reqSendMessage.Attribution, msgOptions)
 
 	case "sticker":
-		resSendMessage.MsgID, err = pkgWhatsApp.WhatsAppSendSticker(ctx, jid, reqSendMessage.RJID, fileBytes)
+		resSendMessage.MsgID, err = pkgWhatsApp.WhatsAppSendSticker(ctx, jid, reqSendMessage.RJID, fileBytes, reqSendMessage.PackName, reqSendMessage.PackPublisher, msgOptions)
 	}
 
 	// Return Internal Server Error
@@ -505,3 +1091,500 @@ func GetGroup(c echo.Context) error {
 
 	return router.ResponseSuccessWithData(c, "Successfully List Joined Groups", group)
 }
+
+// GetMessages
+// @Summary     List Archived Messages
+// @Description List Sent and Received Messages of Authenticated Device from Message Archive
+// @Tags        WhatsApp Message
+// @Produce     json
+// @Param       chat      query  string  false  "Chat ID (MSISDN or Group ID) to Filter"
+// @Param       type      query  string  false  "Message Type to Filter"  Enums(text, image, video, audio, document, sticker, location, live_location, contact, reaction, poll, poll_vote)
+// @Param       since     query  int     false  "Minimum Message Timestamp (Unix Seconds)"
+// @Param       until     query  int     false  "Maximum Message Timestamp (Unix Seconds)"
+// @Param       limit     query  int     false  "Maximum Messages per Page"  default(50)
+// @Param       cursor    query  string  false  "Next Page Cursor from Previous Response"
+// @Success     200
+// @Security    BearerAuth
+// @Router      /api/v1/whatsapp/messages [get]
+func GetMessages(c echo.Context) error {
+	var err error
+	jid := jwtPayload(c).JID
+
+	var reqMessages pkgWhatsApp.MessageFilter
+	reqMessages.Type = strings.TrimSpace(c.QueryParam("type"))
+	reqMessages.Cursor = strings.TrimSpace(c.QueryParam("cursor"))
+
+	chatJID := strings.TrimSpace(c.QueryParam("chat"))
+	if len(chatJID) > 0 {
+		reqMessages.ChatJID = pkgWhatsApp.WhatsAppComposeJID(chatJID).String()
+	}
+
+	if len(c.QueryParam("since")) > 0 {
+		reqMessages.Since, err = strconv.ParseInt(c.QueryParam("since"), 10, 64)
+		if err != nil {
+			return router.ResponseBadRequest(c, "Invalid Query Value Since")
+		}
+	}
+
+	if len(c.QueryParam("until")) > 0 {
+		reqMessages.Until, err = strconv.ParseInt(c.QueryParam("until"), 10, 64)
+		if err != nil {
+			return router.ResponseBadRequest(c, "Invalid Query Value Until")
+		}
+	}
+
+	if len(c.QueryParam("limit")) > 0 {
+		reqMessages.Limit, err = strconv.Atoi(c.QueryParam("limit"))
+		if err != nil {
+			return router.ResponseBadRequest(c, "Invalid Query Value Limit")
+		}
+	}
+
+	if len(reqMessages.Cursor) > 0 {
+		_, _, err = pkgWhatsApp.WhatsAppMessageDecodeCursor(reqMessages.Cursor)
+		if err != nil {
+			return router.ResponseBadRequest(c, err.Error())
+		}
+	}
+
+	messages, err := pkgWhatsApp.WhatsAppMessageList(jid, reqMessages)
+	if err != nil {
+		return router.ResponseInternalError(c, err.Error())
+	}
+
+	return router.ResponseSuccessWithData(c, "Successfully List Archived Messages", messages)
+}
+
+// GetMessageStatus
+// @Summary     Get Sent Message Delivery Status
+// @Description Get Server Acknowledgement, Delivered, Read and Played Status per Recipient of Sent Message, Group Members Without Receipt are Pending
+// @Tags        WhatsApp Message
+// @Produce     json
+// @Param       msgid     path  string  true  "Message ID"
+// @Success     200
+// @Security    BearerAuth
+// @Router      /api/v1/whatsapp/messages/{msgid}/status [get]
+func GetMessageStatus(c echo.Context) error {
+	var err error
+	jid := jwtPayload(c).JID
+
+	msgID := strings.TrimSpace(c.Param("msgid"))
+	if len(msgID) == 0 {
+		return router.ResponseBadRequest(c, "Missing Path Value Message ID")
+	}
+
+	msgStatus, err := pkgWhatsApp.WhatsAppMessageGetStatus(jid, msgID)
+	if err != nil {
+		if errors.Is(err, pkgWhatsApp.ErrMessageNotFound) {
+			return router.ResponseNotFound(c, err.Error())
+		}
+
+		return router.ResponseInternalError(c, err.Error())
+	}
+
+	return router.ResponseSuccessWithData(c, "Successfully Get Message Status", msgStatus)
+}
+
+// RevokeMessage
+// @Summary     Revoke Sent Message
+// @Description Revoke (Delete for Everyone) Message in WhatsApp Personal ID or Group ID, Group Admin Can Revoke Message Sent by Others
+// @Tags        WhatsApp Message
+// @Produce     json
+// @Param       msgid     path   string  true  "Message ID"
+// @Param       msisdn    query  string  true  "WhatsApp Personal ID or Group ID of The Message"
+// @Param       sender    query  string  false "Message Sender, Required for Group Admin When Message is Not Archived"
+// @Success     200
+// @Security    BearerAuth
+// @Router      /api/v1/whatsapp/messages/{msgid} [delete]
+func RevokeMessage(c echo.Context) error {
+	var err error
+	jid := jwtPayload(c).JID
+
+	msgID := strings.TrimSpace(c.Param("msgid"))
+	if len(msgID) == 0 {
+		return router.ResponseBadRequest(c, "Missing Path Value Message ID")
+	}
+
+	rjid := strings.TrimSpace(c.QueryParam("msisdn"))
+	if len(rjid) == 0 {
+		return router.ResponseBadRequest(c, "Missing Query Value MSISDN")
+	}
+
+	err = pkgWhatsApp.WhatsAppRevokeMessage(c.Request().Context(), jid, rjid, msgID, strings.TrimSpace(c.QueryParam("sender")))
+	if err != nil {
+		return router.ResponseInternalError(c, err.Error())
+	}
+
+	return router.ResponseSuccess(c, "Successfully Revoke Message")
+}
+
+// EditMessage
+// @Summary     Edit Sent Text Message
+// @Description Edit Text Message Sent by This Device Within WhatsApp Edit Window, Previous Text is Kept as Edit History
+// @Tags        WhatsApp Message
+// @Accept      multipart/form-data
+// @Produce     json
+// @Param       msgid     path      string  true  "Message ID"
+// @Param       message   formData  string  true  "New Text Message"
+// @Success     200
+// @Security    BearerAuth
+// @Router      /api/v1/whatsapp/messages/{msgid} [patch]
+func EditMessage(c echo.Context) error {
+	var err error
+	jid := jwtPayload(c).JID
+
+	msgID := strings.TrimSpace(c.Param("msgid"))
+	if len(msgID) == 0 {
+		return router.ResponseBadRequest(c, "Missing Path Value Message ID")
+	}
+
+	message := strings.TrimSpace(c.FormValue("message"))
+	if len(message) == 0 {
+		return router.ResponseBadRequest(c, "Missing Form Value Message")
+	}
+
+	archived, err := pkgWhatsApp.WhatsAppEditMessage(c.Request().Context(), jid, msgID, message)
+	if err != nil {
+		switch {
+		case errors.Is(err, pkgWhatsApp.ErrMessageNotFound):
+			return router.ResponseNotFound(c, err.Error())
+		case errors.Is(err, pkgWhatsApp.ErrMessageNotOutgoing),
+			errors.Is(err, pkgWhatsApp.ErrMessageNotText),
+			errors.Is(err, pkgWhatsApp.ErrMessageEditExpired):
+			return router.ResponseBadRequest(c, err.Error())
+		}
+
+		return router.ResponseInternalError(c, err.Error())
+	}
+
+	return router.ResponseSuccessWithData(c, "Successfully Edit Message", archived)
+}
+
+// GetMedia
+// @Summary     Download Received Media
+// @Description Download Media File of Received or Sent Message by Message ID as Attachment with Its Original MIME Type, Redirect to Presigned URL for S3 Media Storage
+// @Tags        WhatsApp Message
+// @Produce     octet-stream
+// @Param       msgid     path  string  true  "Message ID"
+// @Success     200
+// @Security    BearerAuth
+// @Router      /api/v1/whatsapp/media/{msgid} [get]
+func GetMedia(c echo.Context) error {
+	var err error
+	jid := jwtPayload(c).JID
+
+	msgID := strings.TrimSpace(c.Param("msgid"))
+	if len(msgID) == 0 {
+		return router.ResponseBadRequest(c, "Missing Path Value Message ID")
+	}
+
+	media, err := pkgWhatsApp.WhatsAppMediaGet(jid, msgID)
+	if err != nil {
+		if errors.Is(err, pkgWhatsApp.ErrMediaNotFound) {
+			return router.ResponseNotFound(c, err.Error())
+		}
+
+		return router.ResponseInternalError(c, err.Error())
+	}
+
+	// Redirect to Presigned URL When Media Store Supports It
+	mediaURL, isPresigned, err := pkgWhatsApp.WhatsAppMediaPresignURL(media)
+	if err != nil {
+		return router.ResponseInternalError(c, err.Error())
+	}
+
+	if isPresigned {
+		return c.Redirect(http.StatusTemporaryRedirect, mediaURL)
+	}
+
+	mediaReader, err := pkgWhatsApp.WhatsAppMediaOpen(media)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return router.ResponseNotFound(c, pkgWhatsApp.ErrMediaNotFound.Error())
+		}
+
+		return router.ResponseInternalError(c, err.Error())
+	}
+	defer mediaReader.Close()
+
+	// Always Serve Media as Download
+	// So Sender Controlled Content is Not Rendered by Browser
+	mediaDisposition := "attachment"
+	if len(media.FileName) > 0 {
+		mediaDisposition = mime.FormatMediaType("attachment", map[string]string{"filename": media.FileName})
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, mediaDisposition)
+	c.Response().Header().Set(echo.HeaderXContentTypeOptions, "nosniff")
+
+	return c.Stream(http.StatusOK, pkgWhatsApp.WhatsAppMediaContentType(media), mediaReader)
+}
+
+// GetPoll
+// @Summary     Get Poll Results
+// @Description Get Aggregated Votes per Option with Voters of Sent or Received Poll Message
+// @Tags        WhatsApp Message
+// @Produce     json
+// @Param       msgid     path  string  true  "Poll Message ID"
+// @Success     200
+// @Security    BearerAuth
+// @Router      /api/v1/whatsapp/polls/{msgid} [get]
+func GetPoll(c echo.Context) error {
+	var err error
+	jid := jwtPayload(c).JID
+
+	msgID := strings.TrimSpace(c.Param("msgid"))
+	if len(msgID) == 0 {
+		return router.ResponseBadRequest(c, "Missing Path Value Message ID")
+	}
+
+	pollResult, err := pkgWhatsApp.WhatsAppPollGet(jid, msgID)
+	if err != nil {
+		if errors.Is(err, pkgWhatsApp.ErrMessageNotFound) || errors.Is(err, pkgWhatsApp.ErrMessageNotPoll) {
+			return router.ResponseNotFound(c, err.Error())
+		}
+
+		return router.ResponseInternalError(c, err.Error())
+	}
+
+	return router.ResponseSuccessWithData(c, "Successfully Get Poll Results", pollResult)
+}
+
+// GetWebhook
+// @Summary     Get Webhook Configuration
+// @Description Get Webhook Configuration for Authenticated Device, Secret is Not Returned
+// @Tags        WhatsApp Webhook
+// @Produce     json
+// @Success     200
+// @Security    BearerAuth
+// @Router      /api/v1/whatsapp/webhook [get]
+func GetWebhook(c echo.Context) error {
+	var err error
+	jid := jwtPayload(c).JID
+
+	webhook, err := pkgWhatsApp.WhatsAppWebhookGet(jid)
+	if err != nil {
+		return router.ResponseNotFound(c, err.Error())
+	}
+
+	// Webhook Secret is Only Shown Once When Webhook is Set
+	var resWebhook typWhatsApp.ResponseWebhook
+	resWebhook.URL = webhook.URL
+	resWebhook.HasSecret = len(webhook.Secret) > 0
+	resWebhook.Events = webhook.Events
+	resWebhook.UpdatedAt = webhook.UpdatedAt
+
+	return router.ResponseSuccessWithData(c, "Successfully Get Webhook Configuration", resWebhook)
+}
+
+// SetWebhook
+// @Summary     Set Webhook Configuration
+// @Description Set Webhook Callback URL, Signing Secret and Subscribed Event Types for Authenticated Device, Secret is Only Returned Here
+// @Tags        WhatsApp Webhook
+// @Accept      multipart/form-data
+// @Produce     json
+// @Param       url       formData  string  true  "Webhook Callback URL"
+// @Param       secret    formData  string  false "Webhook Signing Secret, Generated When Empty"
+// @Param       events    formData  string  false "Comma Separated Subscribed Event Types, All Events When Empty"
+// @Success     200
+// @Security    BearerAuth
+// @Router      /api/v1/whatsapp/webhook [put]
+func SetWebhook(c echo.Context) error {
+	var err error
+	jid := jwtPayload(c).JID
+
+	var reqWebhook typWhatsApp.RequestWebhook
+	reqWebhook.URL = strings.TrimSpace(c.FormValue("url"))
+	reqWebhook.Secret = strings.TrimSpace(c.FormValue("secret"))
+	reqWebhook.Events = pkgWhatsApp.WhatsAppEventParseTypes(c.FormValue("events"))
+
+	if len(reqWebhook.URL) == 0 {
+		return router.ResponseBadRequest(c, "Missing Form Value URL")
+	}
+
+	webhook, err := pkgWhatsApp.WhatsAppWebhookSet(jid, reqWebhook.URL, reqWebhook.Secret, reqWebhook.Events)
+	if err != nil {
+		return router.ResponseBadRequest(c, err.Error())
+	}
+
+	var resWebhook typWhatsApp.ResponseWebhook
+	resWebhook.URL = webhook.URL
+	resWebhook.Secret = webhook.Secret
+	resWebhook.HasSecret = len(webhook.Secret) > 0
+	resWebhook.Events = webhook.Events
+	resWebhook.UpdatedAt = webhook.UpdatedAt
+
+	return router.ResponseSuccessWithData(c, "Successfully Set Webhook Configuration", resWebhook)
+}
+
+// DeleteWebhook
+// @Summary     Delete Webhook Configuration
+// @Description Delete Webhook Configuration for Authenticated Device
+// @Tags        WhatsApp Webhook
+// @Produce     json
+// @Success     200
+// @Security    BearerAuth
+// @Router      /api/v1/whatsapp/webhook [delete]
+func DeleteWebhook(c echo.Context) error {
+	var err error
+	jid := jwtPayload(c).JID
+
+	err = pkgWhatsApp.WhatsAppWebhookDelete(jid)
+	if err != nil {
+		return router.ResponseNotFound(c, err.Error())
+	}
+
+	return router.ResponseSuccess(c, "Successfully Deleted Webhook Configuration")
+}
+
+// GetWebhookDead
+// @Summary     List Dead-Letter Webhook Deliveries
+// @Description List Webhook Deliveries That Reached Maximum Attempts for Authenticated Device
+// @Tags        WhatsApp Webhook
+// @Produce     json
+// @Success     200
+// @Security    BearerAuth
+// @Router      /api/v1/whatsapp/webhook/dead [get]
+func GetWebhookDead(c echo.Context) error {
+	var err error
+	jid := jwtPayload(c).JID
+
+	deliveries, err := pkgWhatsApp.WhatsAppWebhookDeadList(jid)
+	if err != nil {
+		return router.ResponseInternalError(c, err.Error())
+	}
+
+	return router.ResponseSuccessWithData(c, "Successfully List Dead-Letter Webhook Deliveries", deliveries)
+}
+
+// ReplayWebhookDead
+// @Summary     Replay Dead-Letter Webhook Deliveries
+// @Description Queue Dead-Letter Webhook Deliveries Again for Authenticated Device
+// @Tags        WhatsApp Webhook
+// @Accept      multipart/form-data
+// @Produce     json
+// @Param       id        formData  string  false "Delivery ID, All Deliveries When Empty"
+// @Success     200
+// @Security    BearerAuth
+// @Router      /api/v1/whatsapp/webhook/dead/replay [post]
+func ReplayWebhookDead(c echo.Context) error {
+	var err error
+	jid := jwtPayload(c).JID
+
+	var resWebhookDead typWhatsApp.ResponseWebhookDead
+	resWebhookDead.Affected, err = pkgWhatsApp.WhatsAppWebhookDeadReplay(jid, strings.TrimSpace(c.FormValue("id")))
+	if err != nil {
+		return router.ResponseNotFound(c, err.Error())
+	}
+
+	return router.ResponseSuccessWithData(c, "Successfully Replayed Dead-Letter Webhook Deliveries", resWebhookDead)
+}
+
+// PurgeWebhookDead
+// @Summary     Purge Dead-Letter Webhook Deliveries
+// @Description Delete Dead-Letter Webhook Deliveries for Authenticated Device
+// @Tags        WhatsApp Webhook
+// @Produce     json
+// @Param       id        query  string  false "Delivery ID, All Deliveries When Empty"
+// @Success     200
+// @Security    BearerAuth
+// @Router      /api/v1/whatsapp/webhook/dead [delete]
+func PurgeWebhookDead(c echo.Context) error {
+	var err error
+	jid := jwtPayload(c).JID
+
+	var resWebhookDead typWhatsApp.ResponseWebhookDead
+	resWebhookDead.Affected, err = pkgWhatsApp.WhatsAppWebhookDeadPurge(jid, strings.TrimSpace(c.QueryParam("id")))
+	if err != nil {
+		return router.ResponseNotFound(c, err.Error())
+	}
+
+	return router.ResponseSuccessWithData(c, "Successfully Purged Dead-Letter Webhook Deliveries", resWebhookDead)
+}
+
+// EventStream
+// @Summary     Stream Device Events over WebSocket
+// @Description Upgrade Connection to WebSocket and Stream Device Events (Message, Receipt, Presence, Connection) as JSON Frames
+// @Tags        WhatsApp Event
+// @Produce     json
+// @Param       events    query  string  false "Comma Separated Event Types, All Events When Empty"
+// @Param       token     query  string  false "JWT Token When Authorization Header Can Not be Set"
+// @Success     101
+// @Security    BearerAuth
+// @Router      /api/v1/whatsapp/events/ws [get]
+func EventStream(c echo.Context) error {
+	var err error
+	jid := jwtPayload(c).JID
+
+	// Parse and Validate Event Types Filter
+	events := pkgWhatsApp.WhatsAppEventParseTypes(strings.Join(c.QueryParams()["events"], ","))
+	for _, event := range events {
+		if !pkgWhatsApp.WhatsAppEventIsValid(event) {
+			return router.ResponseBadRequest(c, "Event Type '"+event+"' is Not Valid")
+		}
+	}
+
+	// Upgrade HTTP Connection to WebSocket
+	// Upgrader Already Respond with HTTP Error When Failed
+	wsConn, err := wsUpgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		return nil
+	}
+	defer wsConn.Close()
+
+	// Subscribe to Device Events
+	subscriber := pkgWhatsApp.WhatsAppStreamSubscribe(jid, events)
+	defer pkgWhatsApp.WhatsAppStreamUnsubscribe(subscriber)
+
+	// Mask JID for Logging Information
+	// Request URI is Not Logged Since it May Contain JWT Token
+	maskJID := jid[0:len(jid)-4] + "xxxx"
+
+	log.Print(nil).Info("WebSocket Event Stream Connected for " + maskJID)
+	defer log.Print(nil).Info("WebSocket Event Stream Disconnected for " + maskJID)
+
+	// Read Incoming Frames to Handle Pong and Close Message
+	wsClosed := make(chan struct{})
+
+	wsConn.SetReadLimit(512)
+	_ = wsConn.SetReadDeadline(time.Now().Add(wsPongWait))
+	wsConn.SetPongHandler(func(string) error {
+		return wsConn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	go func() {
+		defer close(wsClosed)
+
+		for {
+			_, _, err := wsConn.ReadMessage()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	// Write Events and Keepalive Ping Until Connection Closed
+	wsPingTicker := time.NewTicker(wsPingPeriod)
+	defer wsPingTicker.Stop()
+
+	for {
+		select {
+		case payload := <-subscriber.Channel:
+			_ = wsConn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+
+			err = wsConn.WriteJSON(payload)
+			if err != nil {
+				return nil
+			}
+
+		case <-wsPingTicker.C:
+			err = wsConn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait))
+			if err != nil {
+				return nil
+			}
+
+		case <-wsClosed:
+			return nil
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 
 	cache "github.com/SporkHubr/echo-http-cache"
 	"github.com/SporkHubr/echo-http-cache/adapter/memory"
@@ -11,7 +12,7 @@ import (
 	"github.com/dimaskiddo/go-whatsapp-multidevice-rest/pkg/log"
 )
 
-func HttpCacheInMemory(cap int, ttl int) echo.MiddlewareFunc {
+func HttpCacheInMemory(cap int, ttl int, skipper middleware.Skipper) echo.MiddlewareFunc {
 	// Check if Cache Capacity is Zero or Less
 	if cap <= 0 {
 		// Set Default Cache Capacity
@@ -51,5 +52,18 @@ func HttpCacheInMemory(cap int, ttl int) echo.MiddlewareFunc {
 	}
 
 	// Return Cache as Echo Middleware
-	return cache.Middleware()
+	// With Skipper for Request That Should Not be Cached
+	cacheMiddleware := cache.Middleware()
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		cacheHandler := cacheMiddleware(next)
+
+		return func(c echo.Context) error {
+			if skipper != nil && skipper(c) {
+				return next(c)
+			}
+
+			return cacheHandler(c)
+		}
+	}
 }
@@ -0,0 +1,116 @@
+package whatsapp
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+var whatsAppDatastoreSchema = []string{
+	`CREATE TABLE IF NOT EXISTS whatsapp_webhooks (
+		jid        TEXT PRIMARY KEY,
+		url        TEXT NOT NULL,
+		secret     TEXT NOT NULL,
+		events     TEXT NOT NULL,
+		updated_at BIGINT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS whatsapp_webhook_outbox (
+		id              TEXT PRIMARY KEY,
+		jid             TEXT NOT NULL,
+		event           TEXT NOT NULL,
+		payload         TEXT NOT NULL,
+		status          TEXT NOT NULL,
+		attempts        INTEGER NOT NULL,
+		next_attempt_at BIGINT NOT NULL,
+		last_error      TEXT NOT NULL,
+		created_at      BIGINT NOT NULL,
+		updated_at      BIGINT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS whatsapp_webhook_outbox_status_idx ON whatsapp_webhook_outbox (status, next_attempt_at)`,
+	`CREATE TABLE IF NOT EXISTS whatsapp_messages (
+		jid              TEXT NOT NULL,
+		id               TEXT NOT NULL,
+		chat_jid         TEXT NOT NULL,
+		sender_jid       TEXT NOT NULL,
+		direction        TEXT NOT NULL,
+		type             TEXT NOT NULL,
+		text             TEXT NOT NULL,
+		media_mimetype   TEXT NOT NULL,
+		media_filename   TEXT NOT NULL,
+		media_filelength BIGINT NOT NULL,
+		raw              TEXT NOT NULL,
+		timestamp        BIGINT NOT NULL,
+		PRIMARY KEY (jid, id)
+	)`,
+	`CREATE INDEX IF NOT EXISTS whatsapp_messages_chat_idx ON whatsapp_messages (jid, chat_jid, timestamp)`,
+	`CREATE INDEX IF NOT EXISTS whatsapp_messages_timestamp_idx ON whatsapp_messages (jid, timestamp)`,
+	`CREATE TABLE IF NOT EXISTS whatsapp_message_edits (
+		jid         TEXT NOT NULL,
+		id          TEXT NOT NULL,
+		text        TEXT NOT NULL,
+		raw         TEXT NOT NULL,
+		replaced_at BIGINT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS whatsapp_message_edits_id_idx ON whatsapp_message_edits (jid, id, replaced_at)`,
+	`CREATE TABLE IF NOT EXISTS whatsapp_message_receipts (
+		jid           TEXT NOT NULL,
+		id            TEXT NOT NULL,
+		recipient_jid TEXT NOT NULL,
+		status        TEXT NOT NULL,
+		timestamp     BIGINT NOT NULL,
+		PRIMARY KEY (jid, id, recipient_jid, status)
+	)`,
+	`CREATE TABLE IF NOT EXISTS whatsapp_poll_votes (
+		jid       TEXT NOT NULL,
+		poll_id   TEXT NOT NULL,
+		voter_jid TEXT NOT NULL,
+		options   TEXT NOT NULL,
+		voted_at  BIGINT NOT NULL,
+		PRIMARY KEY (jid, poll_id, voter_jid)
+	)`,
+	`CREATE TABLE IF NOT EXISTS whatsapp_media (
+		jid         TEXT NOT NULL,
+		id          TEXT NOT NULL,
+		storage_key TEXT NOT NULL,
+		mimetype    TEXT NOT NULL,
+		filename    TEXT NOT NULL,
+		filesize    BIGINT NOT NULL,
+		created_at  BIGINT NOT NULL,
+		PRIMARY KEY (jid, id)
+	)`,
+	`CREATE INDEX IF NOT EXISTS whatsapp_media_created_idx ON whatsapp_media (created_at)`,
+	`CREATE TABLE IF NOT EXISTS whatsapp_live_locations (
+		jid             TEXT NOT NULL,
+		id              TEXT NOT NULL,
+		chat_jid        TEXT NOT NULL,
+		caption         TEXT NOT NULL,
+		latitude        DOUBLE PRECISION NOT NULL,
+		longitude       DOUBLE PRECISION NOT NULL,
+		sequence_number BIGINT NOT NULL,
+		started_at      BIGINT NOT NULL,
+		expires_at      BIGINT NOT NULL,
+		PRIMARY KEY (jid, id)
+	)`,
+}
+
+func WhatsAppDatastoreUpgrade() error {
+	// Create Additional Tables if Not Exist
+	for _, query := range whatsAppDatastoreSchema {
+		_, err := WhatsAppDatastoreDB.Exec(query)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func WhatsAppDatastoreGenerateID() (string, error) {
+	id := make([]byte, 16)
+
+	_, err := rand.Read(id)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(id), nil
+}
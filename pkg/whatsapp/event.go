@@ -0,0 +1,437 @@
+package whatsapp
+
+import (
+	"strings"
+	"time"
+
+	waproto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"github.com/dimaskiddo/go-whatsapp-multidevice-rest/pkg/log"
+)
+
+type EventPayload struct {
+	Event     string      `json:"event"`
+	JID       string      `json:"jid"`
+	Timestamp int64       `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+type EventMessage struct {
+	ID        string                `json:"id"`
+	ChatJID   string                `json:"chat_jid"`
+	SenderJID string                `json:"sender_jid"`
+	PushName  string                `json:"push_name,omitempty"`
+	IsFromMe  bool                  `json:"is_from_me"`
+	IsGroup   bool                  `json:"is_group"`
+	Timestamp int64                 `json:"timestamp"`
+	Type      string                `json:"type"`
+	Text      string                `json:"text,omitempty"`
+	Media     *EventMessageMedia    `json:"media,omitempty"`
+	Location  *EventMessageLocation `json:"location,omitempty"`
+	Contact   *EventMessageContact  `json:"contact,omitempty"`
+	Quoted    *EventMessageQuoted   `json:"quoted,omitempty"`
+}
+
+type EventMessageMedia struct {
+	MimeType   string `json:"mimetype,omitempty"`
+	FileName   string `json:"filename,omitempty"`
+	FileLength uint64 `json:"filelength,omitempty"`
+}
+
+type EventMessageLocation struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Name      string  `json:"name,omitempty"`
+	Address   string  `json:"address,omitempty"`
+	URL       string  `json:"url,omitempty"`
+	IsLive    bool    `json:"is_live,omitempty"`
+}
+
+type EventMessageContact struct {
+	DisplayName string `json:"display_name"`
+	VCard       string `json:"vcard"`
+}
+
+type EventMessageQuoted struct {
+	ID        string `json:"id"`
+	SenderJID string `json:"sender_jid,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Text      string `json:"text,omitempty"`
+}
+
+type EventReceipt struct {
+	ChatJID    string   `json:"chat_jid"`
+	SenderJID  string   `json:"sender_jid"`
+	IsFromMe   bool     `json:"is_from_me"`
+	IsGroup    bool     `json:"is_group"`
+	MessageIDs []string `json:"message_ids"`
+	Type       string   `json:"type"`
+	Timestamp  int64    `json:"timestamp"`
+}
+
+type EventPresence struct {
+	JID         string `json:"jid"`
+	Unavailable bool   `json:"unavailable"`
+	LastSeen    int64  `json:"last_seen,omitempty"`
+}
+
+type EventChatPresence struct {
+	ChatJID   string `json:"chat_jid"`
+	SenderJID string `json:"sender_jid"`
+	State     string `json:"state"`
+	Media     string `json:"media,omitempty"`
+}
+
+type EventConnection struct {
+	State  string `json:"state"`
+	Reason string `json:"reason,omitempty"`
+}
+
+var WhatsAppEventTypes = []string{
+	"message",
+	"receipt",
+	"presence",
+	"chat_presence",
+	"connection",
+	"message.status",
+}
+
+func WhatsAppEventIsValid(event string) bool {
+	for _, eventType := range WhatsAppEventTypes {
+		if eventType == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+func WhatsAppEventParseTypes(events string) []string {
+	var eventTypes []string
+
+	for _, event := range strings.Split(events, ",") {
+		event = strings.TrimSpace(event)
+		if len(event) > 0 {
+			eventTypes = append(eventTypes, event)
+		}
+	}
+
+	return eventTypes
+}
+
+func WhatsAppEventHandler(jid string) func(interface{}) {
+	return func(evt interface{}) {
+		switch evtData := evt.(type) {
+		case *events.Message:
+			// Archive Incoming Message
+			err := WhatsAppMessageArchive(jid, evtData)
+			if err != nil {
+				// Mask JID for Logging Information
+				maskJID := jid[0:len(jid)-4] + "xxxx"
+
+				log.Print(nil).Error("Failed to Archive Incoming Message for " + maskJID + ", " + err.Error())
+			}
+
+			// Record Incoming Poll Vote
+			WhatsAppPollTrack(jid, evtData)
+
+			// Download Incoming Media in Background
+			WhatsAppMediaDownloadQueue(jid, evtData)
+
+			// Publish Incoming Message Event
+			WhatsAppEventPublish(jid, "message", WhatsAppEventComposeMessage(evtData))
+
+		case *events.Receipt:
+			// Publish Message Receipt Event
+			WhatsAppEventPublish(jid, "receipt", WhatsAppEventComposeReceipt(evtData))
+
+			// Track Message Delivery Status
+			WhatsAppReceiptTrack(jid, evtData)
+
+		case *events.Presence:
+			// Publish Contact Presence Event
+			presence := EventPresence{
+				JID:         evtData.From.String(),
+				Unavailable: evtData.Unavailable,
+			}
+
+			if !evtData.LastSeen.IsZero() {
+				presence.LastSeen = evtData.LastSeen.Unix()
+			}
+
+			WhatsAppEventPublish(jid, "presence", presence)
+
+		case *events.ChatPresence:
+			// Publish Chat Presence (Typing or Recording) Event
+			WhatsAppEventPublish(jid, "chat_presence", EventChatPresence{
+				ChatJID:   evtData.Chat.String(),
+				SenderJID: evtData.Sender.ToNonAD().String(),
+				State:     string(evtData.State),
+				Media:     string(evtData.Media),
+			})
+
+		case *events.Connected:
+			// Track and Publish Connection State Event
+			WhatsAppStatusTrack(jid, evtData)
+			WhatsAppEventPublish(jid, "connection", EventConnection{State: "connected"})
+
+		case *events.Disconnected:
+			// Track and Publish Connection State Event
+			WhatsAppStatusTrack(jid, evtData)
+			WhatsAppEventPublish(jid, "connection", EventConnection{State: "disconnected"})
+
+		case *events.LoggedOut:
+			// Track and Publish Connection State Event
+			WhatsAppStatusTrack(jid, evtData)
+			WhatsAppEventPublish(jid, "connection", EventConnection{State: "logged_out", Reason: evtData.Reason.String()})
+
+		case *events.StreamReplaced:
+			// Track and Publish Connection State Event
+			WhatsAppStatusTrack(jid, evtData)
+			WhatsAppEventPublish(jid, "connection", EventConnection{State: "stream_replaced"})
+
+		case *events.ConnectFailure:
+			// Track Connection Failure
+			WhatsAppStatusTrack(jid, evtData)
+		}
+	}
+}
+
+func WhatsAppEventPublish(jid string, event string, data interface{}) {
+	payload := EventPayload{
+		Event:     event,
+		JID:       jid,
+		Timestamp: time.Now().Unix(),
+		Data:      data,
+	}
+
+	// Send Event Payload to Stream Subscribers
+	WhatsAppStreamBroadcast(payload)
+
+	// Queue Event Payload to Webhook Outbox
+	err := WhatsAppWebhookEnqueue(payload)
+	if err != nil {
+		// Mask JID for Logging Information
+		maskJID := jid[0:len(jid)-4] + "xxxx"
+
+		log.Print(nil).Error("Failed to Queue Webhook Event '" + event + "' for " + maskJID + ", " + err.Error())
+	}
+}
+
+func WhatsAppEventComposeMessage(evt *events.Message) EventMessage {
+	msgData := EventMessage{
+		ID:        evt.Info.ID,
+		ChatJID:   evt.Info.Chat.String(),
+		SenderJID: evt.Info.Sender.ToNonAD().String(),
+		PushName:  evt.Info.PushName,
+		IsFromMe:  evt.Info.IsFromMe,
+		IsGroup:   evt.Info.IsGroup,
+		Timestamp: evt.Info.Timestamp.Unix(),
+		Type:      WhatsAppMessageType(evt.Message),
+		Text:      WhatsAppMessageText(evt.Message),
+	}
+
+	// Set Media Information if Message Contains Media
+	// Sent Captioned Document is Archived Still Wrapped
+	msg := WhatsAppMessageUnwrap(evt.Message)
+
+	switch {
+	case msg.GetImageMessage() != nil:
+		msgMedia := msg.GetImageMessage()
+		msgData.Media = &EventMessageMedia{
+			MimeType:   msgMedia.GetMimetype(),
+			FileLength: msgMedia.GetFileLength(),
+		}
+
+	case msg.GetVideoMessage() != nil:
+		msgMedia := msg.GetVideoMessage()
+		msgData.Media = &EventMessageMedia{
+			MimeType:   msgMedia.GetMimetype(),
+			FileLength: msgMedia.GetFileLength(),
+		}
+
+	case msg.GetAudioMessage() != nil:
+		msgMedia := msg.GetAudioMessage()
+		msgData.Media = &EventMessageMedia{
+			MimeType:   msgMedia.GetMimetype(),
+			FileLength: msgMedia.GetFileLength(),
+		}
+
+	case msg.GetDocumentMessage() != nil:
+		msgMedia := msg.GetDocumentMessage()
+		msgData.Media = &EventMessageMedia{
+			MimeType:   msgMedia.GetMimetype(),
+			FileName:   msgMedia.GetFileName(),
+			FileLength: msgMedia.GetFileLength(),
+		}
+
+	case msg.GetStickerMessage() != nil:
+		msgMedia := msg.GetStickerMessage()
+		msgData.Media = &EventMessageMedia{
+			MimeType:   msgMedia.GetMimetype(),
+			FileLength: msgMedia.GetFileLength(),
+		}
+
+	case msg.GetLocationMessage() != nil:
+		msgLocation := msg.GetLocationMessage()
+		msgData.Location = &EventMessageLocation{
+			Latitude:  msgLocation.GetDegreesLatitude(),
+			Longitude: msgLocation.GetDegreesLongitude(),
+			Name:      msgLocation.GetName(),
+			Address:   msgLocation.GetAddress(),
+			URL:       msgLocation.GetURL(),
+		}
+
+	case msg.GetLiveLocationMessage() != nil:
+		msgLocation := msg.GetLiveLocationMessage()
+		msgData.Location = &EventMessageLocation{
+			Latitude:  msgLocation.GetDegreesLatitude(),
+			Longitude: msgLocation.GetDegreesLongitude(),
+			IsLive:    true,
+		}
+
+	case msg.GetContactMessage() != nil:
+		msgContact := msg.GetContactMessage()
+		msgData.Contact = &EventMessageContact{
+			DisplayName: msgContact.GetDisplayName(),
+			VCard:       msgContact.GetVcard(),
+		}
+	}
+
+	// Set Quoted Message Information if Message is a Reply
+	msgContext := WhatsAppMessageContextInfo(evt.Message)
+	if msgContext != nil && len(msgContext.GetStanzaId()) > 0 {
+		msgData.Quoted = &EventMessageQuoted{
+			ID:        msgContext.GetStanzaId(),
+			SenderJID: msgContext.GetParticipant(),
+			Type:      WhatsAppMessageType(msgContext.GetQuotedMessage()),
+			Text:      WhatsAppMessageText(msgContext.GetQuotedMessage()),
+		}
+	}
+
+	return msgData
+}
+
+func WhatsAppEventComposeReceipt(evt *events.Receipt) EventReceipt {
+	// Empty Receipt Type Means Delivered
+	receiptType := string(evt.Type)
+	if evt.Type == events.ReceiptTypeDelivered {
+		receiptType = "delivered"
+	}
+
+	return EventReceipt{
+		ChatJID:    evt.Chat.String(),
+		SenderJID:  evt.Sender.ToNonAD().String(),
+		IsFromMe:   evt.IsFromMe,
+		IsGroup:    evt.IsGroup,
+		MessageIDs: evt.MessageIDs,
+		Type:       receiptType,
+		Timestamp:  evt.Timestamp.Unix(),
+	}
+}
+
+func WhatsAppMessageUnwrap(msg *waproto.Message) *waproto.Message {
+	// Captioned Document is Wrapped Inside Future Proof Message
+	// So Unwrap it to Get The Actual Document Message
+	if msg.GetDocumentWithCaptionMessage().GetMessage() != nil {
+		return msg.GetDocumentWithCaptionMessage().GetMessage()
+	}
+
+	return msg
+}
+
+func WhatsAppMessageType(msg *waproto.Message) string {
+	msg = WhatsAppMessageUnwrap(msg)
+
+	switch {
+	case msg == nil:
+		return "unknown"
+	case msg.Conversation != nil, msg.GetExtendedTextMessage() != nil:
+		return "text"
+	case msg.GetImageMessage() != nil:
+		return "image"
+	case msg.GetVideoMessage() != nil:
+		return "video"
+	case msg.GetAudioMessage() != nil:
+		return "audio"
+	case msg.GetDocumentMessage() != nil:
+		return "document"
+	case msg.GetStickerMessage() != nil:
+		return "sticker"
+	case msg.GetLocationMessage() != nil:
+		return "location"
+	case msg.GetLiveLocationMessage() != nil:
+		return "live_location"
+	case msg.GetContactMessage() != nil:
+		return "contact"
+	case msg.GetReactionMessage() != nil:
+		return "reaction"
+	case WhatsAppMessagePollCreation(msg) != nil:
+		return "poll"
+	case msg.GetPollUpdateMessage() != nil:
+		return "poll_vote"
+	case msg.GetProtocolMessage() != nil:
+		return "protocol"
+	default:
+		return "unknown"
+	}
+}
+
+func WhatsAppMessageText(msg *waproto.Message) string {
+	msg = WhatsAppMessageUnwrap(msg)
+
+	switch {
+	case msg == nil:
+		return ""
+	case msg.Conversation != nil:
+		return msg.GetConversation()
+	case msg.GetExtendedTextMessage() != nil:
+		return msg.GetExtendedTextMessage().GetText()
+	case msg.GetImageMessage() != nil:
+		return msg.GetImageMessage().GetCaption()
+	case msg.GetVideoMessage() != nil:
+		return msg.GetVideoMessage().GetCaption()
+	case msg.GetDocumentMessage() != nil:
+		return msg.GetDocumentMessage().GetCaption()
+	case msg.GetLiveLocationMessage() != nil:
+		return msg.GetLiveLocationMessage().GetCaption()
+	case msg.GetReactionMessage() != nil:
+		return msg.GetReactionMessage().GetText()
+	case WhatsAppMessagePollCreation(msg) != nil:
+		return WhatsAppMessagePollCreation(msg).GetName()
+	default:
+		return ""
+	}
+}
+
+func WhatsAppMessageContextInfo(msg *waproto.Message) *waproto.ContextInfo {
+	msg = WhatsAppMessageUnwrap(msg)
+
+	switch {
+	case msg == nil:
+		return nil
+	case msg.GetExtendedTextMessage() != nil:
+		return msg.GetExtendedTextMessage().GetContextInfo()
+	case msg.GetImageMessage() != nil:
+		return msg.GetImageMessage().GetContextInfo()
+	case msg.GetVideoMessage() != nil:
+		return msg.GetVideoMessage().GetContextInfo()
+	case msg.GetAudioMessage() != nil:
+		return msg.GetAudioMessage().GetContextInfo()
+	case msg.GetDocumentMessage() != nil:
+		return msg.GetDocumentMessage().GetContextInfo()
+	case msg.GetStickerMessage() != nil:
+		return msg.GetStickerMessage().GetContextInfo()
+	case msg.GetLocationMessage() != nil:
+		return msg.GetLocationMessage().GetContextInfo()
+	case msg.GetLiveLocationMessage() != nil:
+		return msg.GetLiveLocationMessage().GetContextInfo()
+	case msg.GetContactMessage() != nil:
+		return msg.GetContactMessage().GetContextInfo()
+	case WhatsAppMessagePollCreation(msg) != nil:
+		return WhatsAppMessagePollCreation(msg).GetContextInfo()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,49 @@
+package whatsapp
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	waproto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+func TestWhatsAppEventComposeMessageDocument(t *testing.T) {
+	document := &waproto.Message{
+		DocumentMessage: &waproto.DocumentMessage{
+			Mimetype:   proto.String("application/pdf"),
+			FileName:   proto.String("report.pdf"),
+			FileLength: proto.Uint64(1024),
+			Caption:    proto.String("Monthly Report"),
+		},
+	}
+
+	tests := []struct {
+		name    string
+		message *waproto.Message
+	}{
+		{name: "Document", message: document},
+		{name: "Document With Caption", message: &waproto.Message{
+			DocumentWithCaptionMessage: &waproto.FutureProofMessage{Message: document},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msgData := WhatsAppEventComposeMessage(&events.Message{Message: tt.message})
+
+			if msgData.Type != "document" || msgData.Text != "Monthly Report" {
+				t.Fatalf("got type %q and text %q", msgData.Type, msgData.Text)
+			}
+
+			if msgData.Media == nil {
+				t.Fatal("media information is not set")
+			}
+
+			if msgData.Media.MimeType != "application/pdf" || msgData.Media.FileName != "report.pdf" || msgData.Media.FileLength != 1024 {
+				t.Fatalf("got media %+v", *msgData.Media)
+			}
+		})
+	}
+}
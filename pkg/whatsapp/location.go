@@ -0,0 +1,319 @@
+package whatsapp
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/sunshineplan/imgconv"
+	"google.golang.org/protobuf/proto"
+
+	"go.mau.fi/whatsmeow"
+	waproto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+
+	"github.com/dimaskiddo/go-whatsapp-multidevice-rest/pkg/log"
+)
+
+type LocationDetail struct {
+	Name      string
+	Address   string
+	URL       string
+	Thumbnail []byte
+}
+
+var (
+	ErrLiveLocationNotFound = errors.New("WhatsApp Live Location is Not Found")
+	ErrLiveLocationExpired  = errors.New("WhatsApp Live Location is Expired")
+)
+
+const (
+	whatsAppLocationThumbnailWidth      = 320
+	whatsAppLiveLocationDurationMin     = 60
+	whatsAppLiveLocationDurationMax     = 8 * 60 * 60
+	WhatsAppLiveLocationDurationDefault = 15 * 60
+)
+
+func WhatsAppLocationValidate(latitude float64, longitude float64) error {
+	if latitude < -90 || latitude > 90 {
+		return errors.New("WhatsApp Location Latitude Should be Between -90 and 90")
+	}
+
+	if longitude < -180 || longitude > 180 {
+		return errors.New("WhatsApp Location Longitude Should be Between -180 and 180")
+	}
+
+	return nil
+}
+
+func WhatsAppLocationThumbnail(thumbBytes []byte) ([]byte, error) {
+	// Creating Location JPEG Thumbnail from Caller Supplied Static Map
+	// With Permanent Width 320px and Preserve Aspect Ratio
+	thumbDecode, err := imgconv.Decode(bytes.NewReader(thumbBytes))
+	if err != nil {
+		return nil, errors.New("Error While Decoding Thumbnail Location Stream")
+	}
+
+	thumbEncode := new(bytes.Buffer)
+
+	err = imgconv.Write(thumbEncode,
+		imgconv.Resize(thumbDecode, imgconv.ResizeOption{Width: whatsAppLocationThumbnailWidth}),
+		imgconv.FormatOption{Format: imgconv.JPEG})
+	if err != nil {
+		return nil, errors.New("Error While Encoding Thumbnail Location Stream")
+	}
+
+	return thumbEncode.Bytes(), nil
+}
+
+func WhatsAppSendLiveLocation(ctx context.Context, jid string, rjid string, latitude float64, longitude float64, caption string, duration int, msgOptions SendOptions) (string, error) {
+	client := WhatsAppClient.Get(jid)
+	if client != nil {
+		var err error
+
+		// Make Sure WhatsApp Client is OK
+		err = WhatsAppIsClientOK(jid)
+		if err != nil {
+			return "", err
+		}
+
+		err = WhatsAppLocationValidate(latitude, longitude)
+		if err != nil {
+			return "", err
+		}
+
+		if duration < whatsAppLiveLocationDurationMin || duration > whatsAppLiveLocationDurationMax {
+			return "", errors.New("WhatsApp Live Location Duration Should be Between 60 Seconds and 8 Hours")
+		}
+
+		// Compose New Remote JID
+		remoteJID := WhatsAppComposeJID(rjid)
+		if WhatsAppGetJID(jid, remoteJID.String()).IsEmpty() {
+			return "", errors.New("WhatsApp Personal ID is Not Registered")
+		}
+
+		// Set Chat Presence
+		WhatsAppComposeStatus(jid, remoteJID, true, false)
+		defer WhatsAppComposeStatus(jid, remoteJID, false, false)
+
+		// Compose WhatsApp Proto
+		msgExtra := whatsmeow.SendRequestExtra{
+			ID: whatsmeow.GenerateMessageID(),
+		}
+		msgContent := &waproto.Message{
+			LiveLocationMessage: &waproto.LiveLocationMessage{
+				DegreesLatitude:  proto.Float64(latitude),
+				DegreesLongitude: proto.Float64(longitude),
+				Caption:          proto.String(caption),
+				SequenceNumber:   proto.Int64(1),
+				TimeOffset:       proto.Uint32(0),
+			},
+		}
+
+		// Track Live Location So Coordinates Can be Updated
+		// Until Duration Ends and Stopped When it Expires
+		startedAt := time.Now().Unix()
+
+		_, err = WhatsAppDatastoreDB.Exec(`INSERT INTO whatsapp_live_locations
+			(jid, id, chat_jid, caption, latitude, longitude, sequence_number, started_at, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			jid, msgExtra.ID, remoteJID.String(), caption, latitude, longitude, 1, startedAt, startedAt+int64(duration))
+		if err != nil {
+			return "", err
+		}
+
+		// Send WhatsApp Message Proto
+		err = WhatsAppSendMessage(ctx, jid, remoteJID, msgContent, msgExtra, msgOptions)
+		if err != nil {
+			_, _ = WhatsAppDatastoreDB.Exec(`DELETE FROM whatsapp_live_locations WHERE jid=$1 AND id=$2`, jid, msgExtra.ID)
+			return "", err
+		}
+
+		return msgExtra.ID, nil
+	}
+
+	// Return Error WhatsApp Client is not Valid
+	return "", errors.New("WhatsApp Client is not Valid")
+}
+
+func WhatsAppUpdateLiveLocation(ctx context.Context, jid string, msgID string, latitude float64, longitude float64) (string, error) {
+	client := WhatsAppClient.Get(jid)
+	if client != nil {
+		var err error
+
+		// Make Sure WhatsApp Client is OK
+		err = WhatsAppIsClientOK(jid)
+		if err != nil {
+			return "", err
+		}
+
+		err = WhatsAppLocationValidate(latitude, longitude)
+		if err != nil {
+			return "", err
+		}
+
+		// Increment Sequence Number Atomically
+		// So Concurrent Updates Never Share The Same Sequence Number
+		var chatJID, caption string
+		var sequenceNumber, startedAt int64
+
+		updatedAt := time.Now().Unix()
+
+		row := WhatsAppDatastoreDB.QueryRow(`UPDATE whatsapp_live_locations
+			SET sequence_number=sequence_number+1, latitude=$1, longitude=$2
+			WHERE jid=$3 AND id=$4 AND expires_at>=$5
+			RETURNING chat_jid, caption, sequence_number, started_at`,
+			latitude, longitude, jid, msgID, updatedAt)
+
+		err = row.Scan(&chatJID, &caption, &sequenceNumber, &startedAt)
+		if err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return "", err
+			}
+
+			var isTracked bool
+
+			err = WhatsAppDatastoreDB.QueryRow(`SELECT EXISTS(SELECT 1 FROM whatsapp_live_locations WHERE jid=$1 AND id=$2)`,
+				jid, msgID).Scan(&isTracked)
+			if err != nil {
+				return "", err
+			}
+
+			if isTracked {
+				return "", ErrLiveLocationExpired
+			}
+
+			return "", ErrLiveLocationNotFound
+		}
+
+		remoteJID, err := types.ParseJID(chatJID)
+		if err != nil {
+			return "", err
+		}
+
+		// Live Location Update is Sent with The Original Message ID
+		// So Recipient Client Applies it to The Same Shared Live Location
+		// Using Sequence Number and Time Offset Instead of Showing New Message
+		err = whatsAppLiveLocationPush(ctx, client, remoteJID, msgID, latitude, longitude, caption, sequenceNumber, uint32(updatedAt-startedAt))
+		if err != nil {
+			return "", err
+		}
+
+		return msgID, nil
+	}
+
+	// Return Error WhatsApp Client is not Valid
+	return "", errors.New("WhatsApp Client is not Valid")
+}
+
+func WhatsAppLiveLocationExpire() {
+	rows, err := WhatsAppDatastoreDB.Query(`SELECT jid, id FROM whatsapp_live_locations WHERE expires_at<$1`, time.Now().Unix())
+	if err != nil {
+		log.Print(nil).Error("Failed to Get Expired WhatsApp Live Location, " + err.Error())
+		return
+	}
+
+	type liveLocationKey struct {
+		JID string
+		ID  string
+	}
+
+	var expiredKeys []liveLocationKey
+	for rows.Next() {
+		var expiredKey liveLocationKey
+
+		err = rows.Scan(&expiredKey.JID, &expiredKey.ID)
+		if err != nil {
+			log.Print(nil).Error("Failed to Read Expired WhatsApp Live Location, " + err.Error())
+			continue
+		}
+
+		expiredKeys = append(expiredKeys, expiredKey)
+	}
+	rows.Close()
+
+	for _, expiredKey := range expiredKeys {
+		err = whatsAppLiveLocationStop(expiredKey.JID, expiredKey.ID)
+		if err != nil {
+			maskJID := expiredKey.JID[0:len(expiredKey.JID)-4] + "xxxx"
+			log.Print(nil).Warn("Failed to Stop WhatsApp Live Location for " + maskJID + ", " + err.Error())
+		}
+	}
+}
+
+func whatsAppLiveLocationStop(jid string, msgID string) error {
+	// Check Client Before Claiming Expired Live Location
+	// So Stop Message is Retried When Device is Back Online
+	client := WhatsAppClient.Get(jid)
+	if client == nil {
+		return errors.New("WhatsApp Client is not Valid")
+	}
+
+	err := WhatsAppIsClientOK(jid)
+	if err != nil {
+		return err
+	}
+
+	// Claim Expired Live Location by Removing it First
+	// So Multiple Replicas Never Send The Same Stop Message
+	var chatJID, caption string
+	var latitude, longitude float64
+	var sequenceNumber, startedAt, expiresAt int64
+
+	row := WhatsAppDatastoreDB.QueryRow(`DELETE FROM whatsapp_live_locations WHERE jid=$1 AND id=$2
+		RETURNING chat_jid, caption, latitude, longitude, sequence_number, started_at, expires_at`, jid, msgID)
+
+	err = row.Scan(&chatJID, &caption, &latitude, &longitude, &sequenceNumber, &startedAt, &expiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+
+		return err
+	}
+
+	remoteJID, err := types.ParseJID(chatJID)
+	if err != nil {
+		return err
+	}
+
+	// Final Update With Time Offset at The End of Duration
+	// Tells Recipient Client That Live Location Sharing Has Stopped
+	err = whatsAppLiveLocationPush(context.Background(), client, remoteJID, msgID, latitude, longitude, caption, sequenceNumber+1, uint32(expiresAt-startedAt))
+	if err != nil {
+		// Put Claimed Live Location Back So Stop Message is Retried
+		_, errRestore := WhatsAppDatastoreDB.Exec(`INSERT INTO whatsapp_live_locations
+			(jid, id, chat_jid, caption, latitude, longitude, sequence_number, started_at, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			jid, msgID, chatJID, caption, latitude, longitude, sequenceNumber, startedAt, expiresAt)
+		if errRestore != nil {
+			log.Print(nil).Error("Failed to Restore Expired WhatsApp Live Location, " + errRestore.Error())
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func whatsAppLiveLocationPush(ctx context.Context, client *whatsmeow.Client, remoteJID types.JID, msgID string, latitude float64, longitude float64, caption string, sequenceNumber int64, timeOffset uint32) error {
+	msgExtra := whatsmeow.SendRequestExtra{
+		ID: msgID,
+	}
+	msgContent := &waproto.Message{
+		LiveLocationMessage: &waproto.LiveLocationMessage{
+			DegreesLatitude:  proto.Float64(latitude),
+			DegreesLongitude: proto.Float64(longitude),
+			Caption:          proto.String(caption),
+			SequenceNumber:   proto.Int64(sequenceNumber),
+			TimeOffset:       proto.Uint32(timeOffset),
+		},
+	}
+
+	// Live Location Update is Not Archived as New Message
+	_, err := client.SendMessage(ctx, remoteJID, msgContent, msgExtra)
+
+	return err
+}
@@ -0,0 +1,36 @@
+package whatsapp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Package Level Variable is Initialized Before Package Init Functions
+// So Tests Use Temporary SQLite Datastore Instead of Configured One
+var whatsAppTestDir = whatsAppTestEnv()
+
+func whatsAppTestEnv() string {
+	testDir, err := os.MkdirTemp("", "whatsapp-test-*")
+	if err != nil {
+		panic(err)
+	}
+
+	os.Setenv("WHATSAPP_DATASTORE_TYPE", "sqlite")
+	os.Setenv("WHATSAPP_DATASTORE_URI", "file:"+filepath.Join(testDir, "whatsapp.db"))
+	os.Setenv("WHATSAPP_USER_AGENT_NAME", "Test")
+	os.Setenv("WHATSAPP_USER_AGENT_TYPE", "chrome")
+	os.Setenv("WHATSAPP_MEDIA_STORAGE_TYPE", "local")
+	os.Setenv("WHATSAPP_MEDIA_STORAGE_PATH", filepath.Join(testDir, "media"))
+
+	return testDir
+}
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+
+	WhatsAppDatastoreDB.Close()
+	os.RemoveAll(whatsAppTestDir)
+
+	os.Exit(code)
+}
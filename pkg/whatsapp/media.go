@@ -0,0 +1,365 @@
+package whatsapp
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"github.com/dimaskiddo/go-whatsapp-multidevice-rest/pkg/env"
+	"github.com/dimaskiddo/go-whatsapp-multidevice-rest/pkg/log"
+)
+
+type MediaStore interface {
+	Put(key string, data []byte, mimeType string) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+type MediaStoreLocal struct {
+	Path string
+}
+
+type MediaFile struct {
+	ID        string `json:"id"`
+	Key       string `json:"-"`
+	MimeType  string `json:"mimetype"`
+	FileName  string `json:"filename,omitempty"`
+	FileSize  int64  `json:"filesize"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type mediaDownloadJob struct {
+	JID   string
+	Event *events.Message
+}
+
+var WhatsAppMediaStore MediaStore
+var WhatsAppMediaRetentionDays int
+var WhatsAppMediaDownloadWorkers int
+var WhatsAppMediaDownloadMaxSize int64
+
+var ErrMediaNotFound = errors.New("WhatsApp Media is Not Found")
+
+const whatsAppMediaDownloadQueueSize = 256
+
+var whatsAppMediaDownloadQueue = make(chan mediaDownloadJob, whatsAppMediaDownloadQueueSize)
+
+func init() {
+	var err error
+
+	mediaStorageType, err := env.GetEnvString("WHATSAPP_MEDIA_STORAGE_TYPE")
+	if err != nil {
+		mediaStorageType = "local"
+	}
+
+	switch strings.ToLower(mediaStorageType) {
+	case "local":
+		mediaStoragePath, err := env.GetEnvString("WHATSAPP_MEDIA_STORAGE_PATH")
+		if err != nil {
+			mediaStoragePath = "dbs/media"
+		}
+
+		WhatsAppMediaStore = &MediaStoreLocal{Path: mediaStoragePath}
+
+	case "s3":
+		WhatsAppMediaStore, err = NewMediaStoreS3()
+		if err != nil {
+			log.Print(nil).Fatal(err.Error())
+		}
+
+	default:
+		log.Print(nil).Fatal("Error Parse Environment Variable for WhatsApp Media Storage Type")
+	}
+
+	WhatsAppMediaRetentionDays, err = env.GetEnvInt("WHATSAPP_MEDIA_RETENTION_DAYS")
+	if err != nil || WhatsAppMediaRetentionDays < 0 {
+		WhatsAppMediaRetentionDays = 30
+	}
+
+	WhatsAppMediaDownloadWorkers, err = env.GetEnvInt("WHATSAPP_MEDIA_DOWNLOAD_WORKERS")
+	if err != nil || WhatsAppMediaDownloadWorkers <= 0 {
+		WhatsAppMediaDownloadWorkers = 4
+	}
+
+	// Zero Maximum Size Means Download Media of Any Size
+	mediaDownloadMaxSize, err := env.GetEnvInt("WHATSAPP_MEDIA_DOWNLOAD_MAX_SIZE_MB")
+	if err != nil || mediaDownloadMaxSize < 0 {
+		mediaDownloadMaxSize = 32
+	}
+	WhatsAppMediaDownloadMaxSize = int64(mediaDownloadMaxSize) * 1024 * 1024
+
+	// Start Media Download Workers
+	for i := 0; i < WhatsAppMediaDownloadWorkers; i++ {
+		go whatsAppMediaDownloadWorker()
+	}
+}
+
+func (s *MediaStoreLocal) path(key string) (string, error) {
+	// Make Sure Key Cannot Escape Storage Directory
+	filePath := filepath.Join(s.Path, filepath.FromSlash(key))
+	if !strings.HasPrefix(filePath, filepath.Clean(s.Path)+string(os.PathSeparator)) {
+		return "", errors.New("WhatsApp Media Key is Not Valid")
+	}
+
+	return filePath, nil
+}
+
+func (s *MediaStoreLocal) Put(key string, data []byte, mimeType string) error {
+	filePath, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(filePath), 0755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filePath, data, 0644)
+}
+
+func (s *MediaStoreLocal) Get(key string) (io.ReadCloser, error) {
+	filePath, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.Open(filePath)
+}
+
+func (s *MediaStoreLocal) Delete(key string) error {
+	filePath, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filePath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func WhatsAppMediaKey(jid string, id string) string {
+	// Message ID is Controlled by Sender
+	// So Storage Key is Derived from Hash of JID and Message ID
+	keyHash := sha256.Sum256([]byte(jid + "\n" + id))
+	key := hex.EncodeToString(keyHash[:])
+
+	return key[0:2] + "/" + key
+}
+
+func WhatsAppMediaDownloadQueue(jid string, evt *events.Message) {
+	// Queue Incoming Media to Download Workers
+	// Skip Download When Workers are Falling Behind
+	select {
+	case whatsAppMediaDownloadQueue <- mediaDownloadJob{JID: jid, Event: evt}:
+	default:
+		// Mask JID for Logging Information
+		maskJID := jid[0:len(jid)-4] + "xxxx"
+
+		log.Print(nil).Warn("Skip Downloading Incoming Media for " + maskJID + ", Download Queue is Full")
+	}
+}
+
+func whatsAppMediaDownloadWorker() {
+	for job := range whatsAppMediaDownloadQueue {
+		err := WhatsAppMediaDownload(job.JID, job.Event)
+		if err != nil {
+			// Mask JID for Logging Information
+			maskJID := job.JID[0:len(job.JID)-4] + "xxxx"
+
+			log.Print(nil).Error("Failed to Download Incoming Media for " + maskJID + ", " + err.Error())
+		}
+	}
+}
+
+func WhatsAppMediaDownload(jid string, evt *events.Message) error {
+	var msgMedia whatsmeow.DownloadableMessage
+	var mimeType, fileName string
+	var fileLength uint64
+
+	// Get Downloadable Media from Message
+	switch {
+	case evt.Message.GetImageMessage() != nil:
+		msgMedia = evt.Message.GetImageMessage()
+		mimeType = evt.Message.GetImageMessage().GetMimetype()
+		fileLength = evt.Message.GetImageMessage().GetFileLength()
+
+	case evt.Message.GetVideoMessage() != nil:
+		msgMedia = evt.Message.GetVideoMessage()
+		mimeType = evt.Message.GetVideoMessage().GetMimetype()
+		fileLength = evt.Message.GetVideoMessage().GetFileLength()
+
+	case evt.Message.GetAudioMessage() != nil:
+		msgMedia = evt.Message.GetAudioMessage()
+		mimeType = evt.Message.GetAudioMessage().GetMimetype()
+		fileLength = evt.Message.GetAudioMessage().GetFileLength()
+
+	case evt.Message.GetDocumentMessage() != nil:
+		msgMedia = evt.Message.GetDocumentMessage()
+		mimeType = evt.Message.GetDocumentMessage().GetMimetype()
+		fileLength = evt.Message.GetDocumentMessage().GetFileLength()
+		fileName = evt.Message.GetDocumentMessage().GetFileName()
+
+	case evt.Message.GetStickerMessage() != nil:
+		msgMedia = evt.Message.GetStickerMessage()
+		mimeType = evt.Message.GetStickerMessage().GetMimetype()
+		fileLength = evt.Message.GetStickerMessage().GetFileLength()
+
+	default:
+		// Message Does Not Contain Media
+		return nil
+	}
+
+	// Skip Media Larger Than Maximum Download Size
+	// So Large Media Does Not Exhaust Memory
+	if WhatsAppMediaDownloadMaxSize > 0 && fileLength > uint64(WhatsAppMediaDownloadMaxSize) {
+		return errors.New("WhatsApp Media is Larger Than Maximum Download Size")
+	}
+
+	client := WhatsAppClient.Get(jid)
+	if client == nil {
+		return errors.New("WhatsApp Client is not Valid")
+	}
+
+	// Download and Decrypt Media
+	mediaBytes, err := client.Download(msgMedia)
+	if err != nil {
+		return err
+	}
+
+	// Save Media to Media Store
+	return WhatsAppMediaSave(jid, evt.Info.ID, mediaBytes, mimeType, fileName)
+}
+
+func WhatsAppMediaSave(jid string, id string, mediaBytes []byte, mimeType string, fileName string) error {
+	mediaKey := WhatsAppMediaKey(jid, id)
+
+	err := WhatsAppMediaStore.Put(mediaKey, mediaBytes, mimeType)
+	if err != nil {
+		return err
+	}
+
+	_, err = WhatsAppDatastoreDB.Exec(`INSERT INTO whatsapp_media (jid, id, storage_key, mimetype, filename, filesize, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (jid, id) DO NOTHING`,
+		jid, id, mediaKey, mimeType, fileName, len(mediaBytes), time.Now().Unix())
+
+	return err
+}
+
+func WhatsAppMediaCache(jid string, id string, mediaBytes []byte, mimeType string, fileName string) {
+	// Cache Sent Media So it Can be Served from Media Endpoint
+	// Failed Cache Should Not Fail The Sent Message
+	err := WhatsAppMediaSave(jid, id, mediaBytes, mimeType, fileName)
+	if err != nil {
+		// Mask JID for Logging Information
+		maskJID := jid[0:len(jid)-4] + "xxxx"
+
+		log.Print(nil).Error("Failed to Cache Sent Media for " + maskJID + ", " + err.Error())
+	}
+}
+
+func WhatsAppMediaGet(jid string, id string) (MediaFile, error) {
+	var media MediaFile
+
+	row := WhatsAppDatastoreDB.QueryRow(`SELECT id, storage_key, mimetype, filename, filesize, created_at FROM whatsapp_media WHERE jid=$1 AND id=$2`, jid, id)
+
+	err := row.Scan(&media.ID, &media.Key, &media.MimeType, &media.FileName, &media.FileSize, &media.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return media, ErrMediaNotFound
+		}
+
+		return media, err
+	}
+
+	return media, nil
+}
+
+func WhatsAppMediaPresignURL(media MediaFile) (string, bool, error) {
+	// Only Media Store Supporting Presigned URL Can Serve Media Directly
+	presigner, ok := WhatsAppMediaStore.(MediaStorePresigner)
+	if !ok {
+		return "", false, nil
+	}
+
+	presignedURL, err := presigner.PresignURL(media.Key, WhatsAppMediaContentType(media), media.FileName)
+	if err != nil {
+		return "", true, err
+	}
+
+	return presignedURL, true, nil
+}
+
+func WhatsAppMediaContentType(media MediaFile) string {
+	// Stored MIME Type Comes from Sender Message
+	// So Fallback to Binary Stream When it is Not Valid
+	_, _, err := mime.ParseMediaType(media.MimeType)
+	if err != nil {
+		return "application/octet-stream"
+	}
+
+	return media.MimeType
+}
+
+func WhatsAppMediaOpen(media MediaFile) (io.ReadCloser, error) {
+	return WhatsAppMediaStore.Get(media.Key)
+}
+
+func WhatsAppMediaCleanup() {
+	// Zero Retention Means Keep Media Forever
+	if WhatsAppMediaRetentionDays == 0 {
+		return
+	}
+
+	expiredAt := time.Now().AddDate(0, 0, -WhatsAppMediaRetentionDays).Unix()
+
+	rows, err := WhatsAppDatastoreDB.Query(`SELECT jid, id, storage_key FROM whatsapp_media WHERE created_at<$1`, expiredAt)
+	if err != nil {
+		log.Print(nil).Error("Failed to Get Expired WhatsApp Media, " + err.Error())
+		return
+	}
+
+	type expiredMedia struct {
+		jid, id, key string
+	}
+
+	var medias []expiredMedia
+	for rows.Next() {
+		var media expiredMedia
+
+		err = rows.Scan(&media.jid, &media.id, &media.key)
+		if err != nil {
+			break
+		}
+
+		medias = append(medias, media)
+	}
+	rows.Close()
+
+	// Delete Expired Media from Media Store and Datastore
+	for _, media := range medias {
+		err = WhatsAppMediaStore.Delete(media.key)
+		if err != nil {
+			log.Print(nil).Error("Failed to Delete Expired WhatsApp Media, " + err.Error())
+			continue
+		}
+
+		_, err = WhatsAppDatastoreDB.Exec(`DELETE FROM whatsapp_media WHERE jid=$1 AND id=$2`, media.jid, media.id)
+		if err != nil {
+			log.Print(nil).Error("Failed to Delete Expired WhatsApp Media, " + err.Error())
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package whatsapp
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/dimaskiddo/go-whatsapp-multidevice-rest/pkg/log"
+)
+
+type AudioVoiceNote struct {
+	Bytes    []byte
+	MimeType string
+	Seconds  uint32
+	Waveform []byte
+}
+
+const (
+	whatsAppAudioVoiceNoteMimeType = "audio/ogg; codecs=opus"
+	whatsAppAudioWaveformSamples   = 64
+	whatsAppAudioWaveformRate      = 8000
+	whatsAppAudioOpusRate          = 48000
+)
+
+func WhatsAppAudioVoiceNote(ctx context.Context, audioBytes []byte) (AudioVoiceNote, error) {
+	var err error
+
+	// Voice Note Must be OGG/Opus
+	// So Convert Other Audio Format Using FFmpeg
+	if !WhatsAppAudioIsOggOpus(audioBytes) {
+		audioBytes, err = WhatsAppMediaFFmpeg(ctx, audioBytes, whatsAppMediaFFmpegFormatAudio,
+			"-vn", "-ac", "1", "-ar", "48000", "-c:a", "libopus", "-b:a", "32k", "-f", "ogg")
+		if err != nil {
+			return AudioVoiceNote{}, errors.New("WhatsApp Voice Note Should be OGG/Opus Audio, " + err.Error())
+		}
+	}
+
+	audioDuration, err := WhatsAppAudioOggOpusDuration(audioBytes)
+	if err != nil {
+		return AudioVoiceNote{}, err
+	}
+
+	voiceNote := AudioVoiceNote{
+		Bytes:    audioBytes,
+		MimeType: whatsAppAudioVoiceNoteMimeType,
+		Seconds:  whatsAppAudioSeconds(audioDuration.Seconds()),
+	}
+
+	// Waveform Needs Decoded Audio Samples
+	// Voice Note is Still Sent Without Waveform When Decoding Failed
+	pcmBytes, err := WhatsAppMediaFFmpeg(ctx, audioBytes, whatsAppMediaFFmpegFormatOgg,
+		"-vn", "-ac", "1", "-ar", "8000", "-f", "s16le")
+	if err != nil {
+		log.Print(nil).Warn("Failed to Decode Voice Note for Waveform, " + err.Error())
+		return voiceNote, nil
+	}
+
+	voiceNote.Waveform = WhatsAppAudioWaveform(pcmBytes)
+
+	// Use Decoded Duration When Granule Position is Missing
+	if voiceNote.Seconds == 0 {
+		voiceNote.Seconds = whatsAppAudioSeconds(float64(len(pcmBytes)/2) / whatsAppAudioWaveformRate)
+	}
+
+	return voiceNote, nil
+}
+
+func whatsAppAudioSeconds(seconds float64) uint32 {
+	// Very Short Audio Should Still Show at Least One Second
+	if seconds > 0 && seconds < 1 {
+		return 1
+	}
+
+	return uint32(math.Round(seconds))
+}
+
+func WhatsAppAudioIsOggOpus(audioBytes []byte) bool {
+	// First OGG Page Body Should Start with Opus Identification Header
+	body, _, _, err := whatsAppAudioOggPage(audioBytes)
+	if err != nil {
+		return false
+	}
+
+	return bytes.HasPrefix(body, []byte("OpusHead"))
+}
+
+func WhatsAppAudioOggOpusDuration(audioBytes []byte) (time.Duration, error) {
+	body, _, _, err := whatsAppAudioOggPage(audioBytes)
+	if err != nil || !bytes.HasPrefix(body, []byte("OpusHead")) || len(body) < 12 {
+		return 0, errors.New("WhatsApp Voice Note is Not Valid OGG/Opus Audio")
+	}
+
+	// Pre-Skip Samples are Not Part of Audio Duration
+	preSkip := int64(binary.LittleEndian.Uint16(body[10:12]))
+
+	// Granule Position of The Last Page is Total Samples at 48 kHz
+	var lastGranule int64
+	for offset := 0; offset < len(audioBytes); {
+		_, granule, pageSize, err := whatsAppAudioOggPage(audioBytes[offset:])
+		if err != nil {
+			break
+		}
+
+		if granule > 0 {
+			lastGranule = granule
+		}
+
+		offset += pageSize
+	}
+
+	if lastGranule <= preSkip {
+		return 0, nil
+	}
+
+	return time.Duration(lastGranule-preSkip) * time.Second / whatsAppAudioOpusRate, nil
+}
+
+func whatsAppAudioOggPage(data []byte) ([]byte, int64, int, error) {
+	// OGG Page Header is 27 Bytes Followed by Segment Table
+	if len(data) < 27 || !bytes.HasPrefix(data, []byte("OggS")) {
+		return nil, 0, 0, errors.New("OGG Page is Not Valid")
+	}
+
+	granule := int64(binary.LittleEndian.Uint64(data[6:14]))
+	segmentCount := int(data[26])
+
+	headerSize := 27 + segmentCount
+	if len(data) < headerSize {
+		return nil, 0, 0, errors.New("OGG Page is Not Valid")
+	}
+
+	bodySize := 0
+	for _, segmentSize := range data[27:headerSize] {
+		bodySize += int(segmentSize)
+	}
+
+	if len(data) < headerSize+bodySize {
+		return nil, 0, 0, errors.New("OGG Page is Not Valid")
+	}
+
+	return data[headerSize : headerSize+bodySize], granule, headerSize + bodySize, nil
+}
+
+func WhatsAppAudioWaveform(pcmBytes []byte) []byte {
+	sampleCount := len(pcmBytes) / 2
+	if sampleCount == 0 {
+		return nil
+	}
+
+	// Average Amplitude of Each Block as Waveform Sample
+	blockSize := sampleCount / whatsAppAudioWaveformSamples
+	if blockSize == 0 {
+		blockSize = 1
+	}
+
+	amplitudes := make([]float64, whatsAppAudioWaveformSamples)
+	maxAmplitude := 0.0
+
+	for i := range amplitudes {
+		start := i * blockSize
+		if start >= sampleCount {
+			break
+		}
+
+		end := start + blockSize
+		if end > sampleCount {
+			end = sampleCount
+		}
+
+		sum := 0.0
+		for j := start; j < end; j++ {
+			sample := int16(binary.LittleEndian.Uint16(pcmBytes[j*2:]))
+			sum += math.Abs(float64(sample))
+		}
+
+		amplitudes[i] = sum / float64(end-start)
+		if amplitudes[i] > maxAmplitude {
+			maxAmplitude = amplitudes[i]
+		}
+	}
+
+	// Normalize Waveform Sample to 0 - 100
+	waveform := make([]byte, whatsAppAudioWaveformSamples)
+	if maxAmplitude > 0 {
+		for i, amplitude := range amplitudes {
+			waveform[i] = byte(math.Round(amplitude / maxAmplitude * 100))
+		}
+	}
+
+	return waveform
+}
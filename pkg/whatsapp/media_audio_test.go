@@ -0,0 +1,211 @@
+package whatsapp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+func testOggPage(granule int64, body []byte) []byte {
+	header := make([]byte, 27)
+	copy(header[0:4], "OggS")
+	binary.LittleEndian.PutUint64(header[6:14], uint64(granule))
+
+	var segments []byte
+	for remaining := len(body); ; remaining -= 255 {
+		if remaining >= 255 {
+			segments = append(segments, 255)
+			continue
+		}
+
+		segments = append(segments, byte(remaining))
+		break
+	}
+	header[26] = byte(len(segments))
+
+	return append(append(header, segments...), body...)
+}
+
+func testOpusHead(preSkip uint16) []byte {
+	head := make([]byte, 19)
+	copy(head[0:8], "OpusHead")
+	head[8] = 1
+	head[9] = 1
+	binary.LittleEndian.PutUint16(head[10:12], preSkip)
+	binary.LittleEndian.PutUint32(head[12:16], whatsAppAudioOpusRate)
+
+	return head
+}
+
+func TestWhatsAppAudioOggPage(t *testing.T) {
+	validPage := testOggPage(960, []byte("body"))
+	largePage := testOggPage(960, bytes.Repeat([]byte{0x01}, 600))
+
+	tests := []struct {
+		name     string
+		data     []byte
+		body     []byte
+		granule  int64
+		pageSize int
+		wantErr  bool
+	}{
+		{name: "Valid", data: validPage, body: []byte("body"), granule: 960, pageSize: len(validPage)},
+		{name: "Multi Segment Body", data: largePage, body: bytes.Repeat([]byte{0x01}, 600), granule: 960, pageSize: len(largePage)},
+		{name: "Trailing Data", data: append(append([]byte{}, validPage...), "OggS"...), body: []byte("body"), granule: 960, pageSize: len(validPage)},
+		{name: "Empty Body", data: testOggPage(0, nil), body: []byte{}, granule: 0, pageSize: 28},
+		{name: "Granule Position Unset", data: testOggPage(-1, []byte("body")), body: []byte("body"), granule: -1, pageSize: len(validPage)},
+		{name: "Empty", data: nil, wantErr: true},
+		{name: "Bad Capture Pattern", data: append([]byte("OggX"), validPage[4:]...), wantErr: true},
+		{name: "Truncated Header", data: validPage[:26], wantErr: true},
+		{name: "Truncated Segment Table", data: largePage[:28], wantErr: true},
+		{name: "Truncated Body", data: validPage[:len(validPage)-1], wantErr: true},
+		{name: "Oversized Segment Count", data: append(append([]byte{}, validPage[:26]...), 0xFF, 0x04), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, granule, pageSize, err := whatsAppAudioOggPage(tt.data)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !bytes.Equal(body, tt.body) || granule != tt.granule || pageSize != tt.pageSize {
+				t.Fatalf("got (%q, %d, %d), want (%q, %d, %d)", body, granule, pageSize, tt.body, tt.granule, tt.pageSize)
+			}
+		})
+	}
+}
+
+func TestWhatsAppAudioOggOpusDuration(t *testing.T) {
+	headPage := testOggPage(0, testOpusHead(312))
+	tagsPage := testOggPage(0, []byte("OpusTags"))
+
+	stream := func(pages ...[]byte) []byte {
+		return bytes.Join(append([][]byte{headPage, tagsPage}, pages...), nil)
+	}
+
+	tests := []struct {
+		name     string
+		data     []byte
+		duration time.Duration
+		wantErr  bool
+	}{
+		{name: "Valid", data: stream(testOggPage(48000+312, []byte("a")), testOggPage(5*48000+312, []byte("b"))), duration: 5 * time.Second},
+		{name: "Last Page Granule Unset", data: stream(testOggPage(3*48000+312, []byte("a")), testOggPage(-1, []byte("b"))), duration: 3 * time.Second},
+		{name: "Truncated Last Page", data: stream(testOggPage(2*48000+312, []byte("a")), testOggPage(9*48000+312, []byte("b"))[:20]), duration: 2 * time.Second},
+		{name: "Granule Below Pre-Skip", data: stream(testOggPage(100, []byte("a"))), duration: 0},
+		{name: "Header Only", data: stream(), duration: 0},
+		{name: "Not Opus", data: testOggPage(0, []byte("\x01vorbis")), wantErr: true},
+		{name: "Short Opus Head", data: testOggPage(0, []byte("OpusHead\x01")), wantErr: true},
+		{name: "Not OGG", data: []byte("RIFF\x00\x00\x00\x00WAVE"), wantErr: true},
+		{name: "Empty", data: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			duration, err := WhatsAppAudioOggOpusDuration(tt.data)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if duration != tt.duration {
+				t.Fatalf("got %v, want %v", duration, tt.duration)
+			}
+		})
+	}
+}
+
+func TestWhatsAppAudioIsOggOpus(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want bool
+	}{
+		{name: "Opus", data: testOggPage(0, testOpusHead(312)), want: true},
+		{name: "Vorbis", data: testOggPage(0, []byte("\x01vorbis")), want: false},
+		{name: "Truncated", data: testOggPage(0, testOpusHead(312))[:30], want: false},
+		{name: "Empty", data: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WhatsAppAudioIsOggOpus(tt.data); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWhatsAppAudioWaveform(t *testing.T) {
+	pcm := func(samples ...int16) []byte {
+		data := make([]byte, len(samples)*2)
+		for i, sample := range samples {
+			binary.LittleEndian.PutUint16(data[i*2:], uint16(sample))
+		}
+		return data
+	}
+
+	ramp := make([]int16, whatsAppAudioWaveformSamples*10)
+	for i := range ramp {
+		ramp[i] = int16(i / 10 * 100)
+	}
+
+	tests := []struct {
+		name  string
+		data  []byte
+		check func(t *testing.T, waveform []byte)
+	}{
+		{name: "Empty", data: nil, check: func(t *testing.T, waveform []byte) {
+			if waveform != nil {
+				t.Fatalf("got %v, want nil", waveform)
+			}
+		}},
+		{name: "Single Odd Byte", data: []byte{0x7F}, check: func(t *testing.T, waveform []byte) {
+			if waveform != nil {
+				t.Fatalf("got %v, want nil", waveform)
+			}
+		}},
+		{name: "Silence", data: pcm(make([]int16, 1000)...), check: func(t *testing.T, waveform []byte) {
+			if !bytes.Equal(waveform, make([]byte, whatsAppAudioWaveformSamples)) {
+				t.Fatalf("got %v, want all zero", waveform)
+			}
+		}},
+		{name: "Fewer Samples Than Waveform", data: append(pcm(-32768, 16384), 0x01), check: func(t *testing.T, waveform []byte) {
+			if len(waveform) != whatsAppAudioWaveformSamples || waveform[0] != 100 || waveform[1] != 50 || waveform[2] != 0 {
+				t.Fatalf("got %v", waveform)
+			}
+		}},
+		{name: "Ramp", data: pcm(ramp...), check: func(t *testing.T, waveform []byte) {
+			if len(waveform) != whatsAppAudioWaveformSamples || waveform[0] != 0 || waveform[len(waveform)-1] != 100 {
+				t.Fatalf("got %v", waveform)
+			}
+
+			for i := 1; i < len(waveform); i++ {
+				if waveform[i] < waveform[i-1] {
+					t.Fatalf("waveform is not increasing at %d: %v", i, waveform)
+				}
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.check(t, WhatsAppAudioWaveform(tt.data))
+		})
+	}
+}
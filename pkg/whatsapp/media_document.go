@@ -0,0 +1,143 @@
+package whatsapp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/sunshineplan/imgconv"
+
+	"github.com/dimaskiddo/go-whatsapp-multidevice-rest/pkg/env"
+)
+
+type DocumentPreview struct {
+	PageCount       uint32
+	Thumbnail       []byte
+	ThumbnailWidth  uint32
+	ThumbnailHeight uint32
+}
+
+var WhatsAppMediaPDFToPPMPath string
+
+const (
+	whatsAppDocumentThumbnailWidth = 480
+	whatsAppDocumentRenderTimeout  = 30 * time.Second
+)
+
+func init() {
+	var err error
+
+	// PDF Library Should Not Create Configuration Directory
+	// Or Exit The Process When Home Directory is Not Writable
+	api.DisableConfigDir()
+
+	WhatsAppMediaPDFToPPMPath, err = env.GetEnvString("WHATSAPP_MEDIA_PDFTOPPM_PATH")
+	if err != nil {
+		WhatsAppMediaPDFToPPMPath = "pdftoppm"
+	}
+}
+
+func WhatsAppDocumentIsPDF(fileBytes []byte, fileType string) bool {
+	return fileType == "application/pdf" || bytes.HasPrefix(fileBytes, []byte("%PDF-"))
+}
+
+func WhatsAppDocumentMimeType(fileType string, fileName string) string {
+	// Client Shows Document Icon Based on MIME Type
+	// So Guess it from File Extension When Not Provided
+	if len(fileType) == 0 || fileType == "application/octet-stream" {
+		extType := mime.TypeByExtension(filepath.Ext(fileName))
+		if len(extType) > 0 {
+			extType, _, _ = mime.ParseMediaType(extType)
+			return extType
+		}
+
+		return "application/octet-stream"
+	}
+
+	return fileType
+}
+
+func WhatsAppDocumentPDFPreview(ctx context.Context, fileBytes []byte) (DocumentPreview, error) {
+	var preview DocumentPreview
+
+	pageCount, err := api.PageCount(bytes.NewReader(fileBytes), pdfcpu.NewDefaultConfiguration())
+	if err != nil {
+		return preview, errors.New("WhatsApp Document is Not Valid PDF Document")
+	}
+
+	preview.PageCount = uint32(pageCount)
+
+	// Render First Page to Create Document JPEG Thumbnail
+	// Client Shows Generic Document Icon When Thumbnail is Not Available
+	pageBytes, err := WhatsAppDocumentPDFRender(ctx, fileBytes)
+	if err != nil {
+		return preview, err
+	}
+
+	pdfThumbDecode, err := imgconv.Decode(bytes.NewReader(pageBytes))
+	if err != nil {
+		return preview, errors.New("Error While Decoding Thumbnail Document Stream")
+	}
+
+	pdfThumbResize := imgconv.Resize(pdfThumbDecode, imgconv.ResizeOption{Width: whatsAppDocumentThumbnailWidth})
+	pdfThumbEncode := new(bytes.Buffer)
+
+	err = imgconv.Write(pdfThumbEncode, pdfThumbResize, imgconv.FormatOption{Format: imgconv.JPEG})
+	if err != nil {
+		return preview, errors.New("Error While Encoding Thumbnail Document Stream")
+	}
+
+	preview.Thumbnail = pdfThumbEncode.Bytes()
+	preview.ThumbnailWidth = uint32(pdfThumbResize.Bounds().Dx())
+	preview.ThumbnailHeight = uint32(pdfThumbResize.Bounds().Dy())
+
+	return preview, nil
+}
+
+func WhatsAppDocumentPDFRender(ctx context.Context, fileBytes []byte) ([]byte, error) {
+	pdfToPPMPath, err := exec.LookPath(WhatsAppMediaPDFToPPMPath)
+	if err != nil {
+		return nil, errors.New("PDFToPPM is Not Available")
+	}
+
+	// Write Input to Temporary File
+	// Since PDF Needs Seekable Input
+	inputFile, err := os.CreateTemp("", "whatsapp-document-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(inputFile.Name())
+
+	_, err = inputFile.Write(fileBytes)
+	inputFile.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, whatsAppDocumentRenderTimeout)
+	defer cancel()
+
+	// Render Only First Page as PNG Image to Standard Output
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, pdfToPPMPath, "-f", "1", "-l", "1", "-singlefile", "-png",
+		"-scale-to-x", strconv.Itoa(whatsAppDocumentThumbnailWidth), "-scale-to-y", "-1", inputFile.Name(), "-")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+	if err != nil {
+		return nil, errors.New("PDFToPPM Failed, " + strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,247 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dimaskiddo/go-whatsapp-multidevice-rest/pkg/env"
+	"github.com/dimaskiddo/go-whatsapp-multidevice-rest/pkg/log"
+)
+
+type MediaFetched struct {
+	Bytes    []byte
+	MimeType string
+	FileName string
+}
+
+var (
+	WhatsAppMediaFetchAllowedHosts []string
+	WhatsAppMediaFetchMaxSize      int64
+	WhatsAppMediaFetchTimeout      time.Duration
+)
+
+var whatsAppMediaFetchClient *http.Client
+
+const whatsAppMediaFetchMaxRedirects = 5
+
+func init() {
+	var err error
+
+	// Empty Allowed Hosts Means Fetching Media from URL is Disabled
+	// Allowing Any Host with "*" is Not Supported
+	mediaFetchAllowedHosts, _ := env.GetEnvString("WHATSAPP_MEDIA_FETCH_ALLOWED_HOSTS")
+	for _, host := range strings.Split(mediaFetchAllowedHosts, ",") {
+		host = strings.ToLower(strings.TrimSpace(host))
+		if host == "*" {
+			log.Print(nil).Warn("Ignoring Wildcard Host in WhatsApp Media Fetch Allowed Hosts, Please List Allowed Hosts Explicitly")
+			continue
+		}
+
+		if len(host) > 0 {
+			WhatsAppMediaFetchAllowedHosts = append(WhatsAppMediaFetchAllowedHosts, host)
+		}
+	}
+
+	mediaFetchMaxSize, err := env.GetEnvInt("WHATSAPP_MEDIA_FETCH_MAX_SIZE_MB")
+	if err != nil || mediaFetchMaxSize <= 0 {
+		mediaFetchMaxSize = 16
+	}
+	WhatsAppMediaFetchMaxSize = int64(mediaFetchMaxSize) * 1024 * 1024
+
+	mediaFetchTimeout, err := env.GetEnvInt("WHATSAPP_MEDIA_FETCH_TIMEOUT_SECONDS")
+	if err != nil || mediaFetchTimeout <= 0 {
+		mediaFetchTimeout = 30
+	}
+	WhatsAppMediaFetchTimeout = time.Duration(mediaFetchTimeout) * time.Second
+
+	// Check Resolved IP Address on Every Connection
+	// So Allowed Host, Redirect or DNS Rebinding Cannot Reach Internal Network
+	mediaFetchDialer := &net.Dialer{
+		Timeout: WhatsAppMediaFetchTimeout,
+		Control: whatsAppMediaFetchDialControl,
+	}
+
+	// Connect Directly Without Proxy From Environment
+	// Since Proxy Would Make Dialer Only Check Proxy Address
+	mediaFetchTransport := http.DefaultTransport.(*http.Transport).Clone()
+	mediaFetchTransport.Proxy = nil
+	mediaFetchTransport.DialContext = mediaFetchDialer.DialContext
+
+	whatsAppMediaFetchClient = &http.Client{
+		Timeout:   WhatsAppMediaFetchTimeout,
+		Transport: mediaFetchTransport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= whatsAppMediaFetchMaxRedirects {
+				return errors.New("WhatsApp Media URL Has Too Many Redirects")
+			}
+
+			// Redirect Target Must Also be Allowed
+			return WhatsAppMediaFetchIsAllowed(req.URL)
+		},
+	}
+}
+
+func WhatsAppMediaFetchIsAllowed(mediaURL *url.URL) error {
+	if mediaURL.Scheme != "http" && mediaURL.Scheme != "https" {
+		return errors.New("WhatsApp Media URL Scheme Should be HTTP or HTTPS")
+	}
+
+	if len(WhatsAppMediaFetchAllowedHosts) == 0 {
+		return errors.New("WhatsApp Media URL Fetching is Disabled")
+	}
+
+	// Allowed Host Can be Exact Host or Wildcard Subdomain Like "*.example.com"
+	host := strings.ToLower(mediaURL.Hostname())
+	for _, allowedHost := range WhatsAppMediaFetchAllowedHosts {
+		if allowedHost == host {
+			return nil
+		}
+
+		if strings.HasPrefix(allowedHost, "*.") && strings.HasSuffix(host, allowedHost[1:]) {
+			return nil
+		}
+	}
+
+	return errors.New("WhatsApp Media URL Host " + host + " is Not Allowed")
+}
+
+func whatsAppMediaFetchDialControl(network string, address string, conn syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return errors.New("WhatsApp Media URL Address " + host + " is Not Valid")
+	}
+
+	if !WhatsAppMediaFetchIsPublicIP(ip) {
+		return errors.New("WhatsApp Media URL Address " + host + " is Not Allowed")
+	}
+
+	return nil
+}
+
+func WhatsAppMediaFetchIsPublicIP(ip net.IP) bool {
+	// Reject Loopback, Link-Local, Private and Other Non Routable Address
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsPrivate() || ip.IsUnspecified() {
+		return false
+	}
+
+	// Reject Carrier-Grade NAT Shared Address Space
+	if ip4 := ip.To4(); ip4 != nil && ip4[0] == 100 && ip4[1]&0xC0 == 64 {
+		return false
+	}
+
+	return true
+}
+
+func WhatsAppMediaFetch(ctx context.Context, rawURL string) (MediaFetched, error) {
+	var media MediaFetched
+
+	mediaURL, err := url.Parse(rawURL)
+	if err != nil {
+		return media, errors.New("WhatsApp Media URL is Not Valid")
+	}
+
+	err = WhatsAppMediaFetchIsAllowed(mediaURL)
+	if err != nil {
+		return media, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, mediaURL.String(), nil)
+	if err != nil {
+		return media, err
+	}
+
+	resp, err := whatsAppMediaFetchClient.Do(req)
+	if err != nil {
+		return media, errors.New("Error While Fetching Media from URL, " + err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return media, errors.New("Error While Fetching Media from URL, Got HTTP Status " + strconv.Itoa(resp.StatusCode))
+	}
+
+	// Reject Early When Declared Size is Already Too Large
+	// Then Limit The Read Since Declared Size Might be Missing or Wrong
+	if resp.ContentLength > WhatsAppMediaFetchMaxSize {
+		return media, errors.New("WhatsApp Media from URL Exceeds Maximum Size")
+	}
+
+	media.Bytes, err = io.ReadAll(io.LimitReader(resp.Body, WhatsAppMediaFetchMaxSize+1))
+	if err != nil {
+		return media, errors.New("Error While Fetching Media from URL, " + err.Error())
+	}
+
+	if int64(len(media.Bytes)) > WhatsAppMediaFetchMaxSize {
+		return media, errors.New("WhatsApp Media from URL Exceeds Maximum Size")
+	}
+
+	// Get MIME Type from Response Header
+	// Or Detect it from Content When Not Provided
+	media.MimeType, _, _ = mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if len(media.MimeType) == 0 || media.MimeType == "application/octet-stream" {
+		media.MimeType, _, _ = mime.ParseMediaType(http.DetectContentType(media.Bytes))
+	}
+
+	// Get File Name from Final URL Path
+	fileName := path.Base(resp.Request.URL.Path)
+	if fileName != "/" && fileName != "." {
+		media.FileName = fileName
+	}
+
+	return media, nil
+}
+
+func WhatsAppMediaDecodeBase64(data string) (MediaFetched, error) {
+	var media MediaFetched
+	var err error
+
+	// Base64 Content Can be Written as Data URI
+	// Like "data:image/png;base64,iVBORw0..."
+	if strings.HasPrefix(data, "data:") {
+		buffers := strings.SplitN(data[len("data:"):], ",", 2)
+		if len(buffers) != 2 || !strings.HasSuffix(buffers[0], ";base64") {
+			return media, errors.New("WhatsApp Media Base64 Data URI is Not Valid")
+		}
+
+		media.MimeType, _, _ = mime.ParseMediaType(strings.TrimSuffix(buffers[0], ";base64"))
+		data = buffers[1]
+	}
+
+	// Reject Before Decoding When Content Would Exceed Maximum Size
+	data = strings.TrimSpace(data)
+	if int64(base64.StdEncoding.DecodedLen(len(data))) > WhatsAppMediaFetchMaxSize+2 {
+		return media, errors.New("WhatsApp Media Base64 Content Exceeds Maximum Size")
+	}
+
+	media.Bytes, err = base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return media, errors.New("WhatsApp Media Base64 Content is Not Valid")
+	}
+
+	if int64(len(media.Bytes)) > WhatsAppMediaFetchMaxSize {
+		return media, errors.New("WhatsApp Media Base64 Content Exceeds Maximum Size")
+	}
+
+	if len(media.MimeType) == 0 {
+		media.MimeType, _, _ = mime.ParseMediaType(http.DetectContentType(media.Bytes))
+	}
+
+	return media, nil
+}
@@ -0,0 +1,117 @@
+package whatsapp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/dimaskiddo/go-whatsapp-multidevice-rest/pkg/env"
+)
+
+var WhatsAppMediaFFmpegPath string
+
+const whatsAppMediaFFmpegTimeout = 60 * time.Second
+
+// Demuxers Allowed to Read Caller Supplied Media
+// Playlist Demuxer Like HLS or Concat is Never Allowed
+// Since it Can Read Other Local Files or Make Network Requests
+const (
+	whatsAppMediaFFmpegFormatAudio = "mp3,wav,ogg,flac,aac,mov,mp4,m4a,3gp,3g2,mj2,matroska,webm,amr"
+	whatsAppMediaFFmpegFormatVideo = "mov,mp4,m4a,3gp,3g2,mj2,matroska,webm"
+	whatsAppMediaFFmpegFormatOgg   = "ogg"
+	whatsAppMediaFFmpegFormatGIF   = "gif"
+)
+
+func init() {
+	var err error
+
+	WhatsAppMediaFFmpegPath, err = env.GetEnvString("WHATSAPP_MEDIA_FFMPEG_PATH")
+	if err != nil {
+		WhatsAppMediaFFmpegPath = "ffmpeg"
+	}
+}
+
+func WhatsAppMediaFFmpeg(ctx context.Context, inputBytes []byte, inputFormat string, outputArgs ...string) ([]byte, error) {
+	return whatsAppMediaFFmpegRun(ctx, inputBytes, inputFormat, "", outputArgs...)
+}
+
+func WhatsAppMediaFFmpegFile(ctx context.Context, inputBytes []byte, inputFormat string, outputArgs ...string) ([]byte, error) {
+	// Some Container Like MP4 Needs Seekable Output
+	// To Write Index at The Beginning of File
+	outputFile, err := os.CreateTemp("", "whatsapp-media-*")
+	if err != nil {
+		return nil, err
+	}
+	outputFile.Close()
+	defer os.Remove(outputFile.Name())
+
+	_, err = whatsAppMediaFFmpegRun(ctx, inputBytes, inputFormat, outputFile.Name(), outputArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(outputFile.Name())
+}
+
+func whatsAppMediaFFmpegRun(ctx context.Context, inputBytes []byte, inputFormat string, outputPath string, outputArgs ...string) ([]byte, error) {
+	if len(inputFormat) == 0 {
+		return nil, errors.New("FFmpeg Input Format is Not Specified")
+	}
+
+	ffmpegPath, err := exec.LookPath(WhatsAppMediaFFmpegPath)
+	if err != nil {
+		return nil, errors.New("FFmpeg is Not Available")
+	}
+
+	// Some Container Like MP4 Cannot be Read from Pipe
+	// So Write Input to Temporary File
+	inputFile, err := os.CreateTemp("", "whatsapp-media-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(inputFile.Name())
+
+	_, err = inputFile.Write(inputBytes)
+	inputFile.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, whatsAppMediaFFmpegTimeout)
+	defer cancel()
+
+	// Write Output to Standard Output When Output Path is Empty
+	if len(outputPath) == 0 {
+		outputPath = "pipe:1"
+	}
+
+	// Input is Only Read from Local Temporary File
+	// Using Pinned Demuxer or Demuxer Whitelist of The Call Site
+	args := []string{"-hide_banner", "-loglevel", "error", "-protocol_whitelist", "file"}
+	if strings.Contains(inputFormat, ",") {
+		args = append(args, "-format_whitelist", inputFormat)
+	} else {
+		args = append(args, "-f", inputFormat)
+	}
+
+	args = append(args, "-i", inputFile.Name())
+	args = append(args, outputArgs...)
+	args = append(args, "-y", outputPath)
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, ffmpegPath, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+	if err != nil {
+		return nil, errors.New("FFmpeg Failed, " + strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,142 @@
+package whatsapp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/dimaskiddo/go-whatsapp-multidevice-rest/pkg/env"
+)
+
+type MediaStoreS3 struct {
+	Client        *minio.Client
+	Bucket        string
+	PresignExpiry time.Duration
+}
+
+type MediaStorePresigner interface {
+	PresignURL(key string, mimeType string, fileName string) (string, error)
+}
+
+func NewMediaStoreS3() (*MediaStoreS3, error) {
+	var err error
+
+	endpoint, err := env.GetEnvString("WHATSAPP_MEDIA_S3_ENDPOINT")
+	if err != nil {
+		return nil, errors.New("Error Parse Environment Variable for WhatsApp Media S3 Endpoint")
+	}
+
+	bucket, err := env.GetEnvString("WHATSAPP_MEDIA_S3_BUCKET")
+	if err != nil {
+		return nil, errors.New("Error Parse Environment Variable for WhatsApp Media S3 Bucket")
+	}
+
+	accessKey, _ := env.GetEnvString("WHATSAPP_MEDIA_S3_ACCESS_KEY")
+	secretKey, _ := env.GetEnvString("WHATSAPP_MEDIA_S3_SECRET_KEY")
+	region, _ := env.GetEnvString("WHATSAPP_MEDIA_S3_REGION")
+
+	useSSL, err := env.GetEnvBool("WHATSAPP_MEDIA_S3_USE_SSL")
+	if err != nil {
+		useSSL = true
+	}
+
+	// Endpoint Can be Written as URL
+	// Then Scheme Decide Whether SSL is Used
+	if strings.Contains(endpoint, "://") {
+		endpointURL, err := url.Parse(endpoint)
+		if err != nil || len(endpointURL.Host) == 0 {
+			return nil, errors.New("Error Parse Environment Variable for WhatsApp Media S3 Endpoint")
+		}
+
+		endpoint = endpointURL.Host
+		useSSL = endpointURL.Scheme == "https"
+	}
+
+	// Path Style is Needed by MinIO and Most Self-Hosted S3
+	bucketLookup := minio.BucketLookupAuto
+
+	usePathStyle, err := env.GetEnvBool("WHATSAPP_MEDIA_S3_PATH_STYLE")
+	if err == nil {
+		if usePathStyle {
+			bucketLookup = minio.BucketLookupPath
+		} else {
+			bucketLookup = minio.BucketLookupDNS
+		}
+	}
+
+	presignExpiry, err := env.GetEnvInt("WHATSAPP_MEDIA_S3_PRESIGN_EXPIRY_SECONDS")
+	if err != nil || presignExpiry <= 0 {
+		presignExpiry = 300
+	}
+
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:        credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure:       useSSL,
+		Region:       region,
+		BucketLookup: bucketLookup,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MediaStoreS3{
+		Client:        client,
+		Bucket:        bucket,
+		PresignExpiry: time.Duration(presignExpiry) * time.Second,
+	}, nil
+}
+
+func (s *MediaStoreS3) Put(key string, data []byte, mimeType string) error {
+	_, err := s.Client.PutObject(context.Background(), s.Bucket, key, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
+		ContentType: mimeType,
+	})
+
+	return err
+}
+
+func (s *MediaStoreS3) Get(key string) (io.ReadCloser, error) {
+	// Check Object First Since Get Object Error is Lazy
+	_, err := s.Client.StatObject(context.Background(), s.Bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, os.ErrNotExist
+		}
+
+		return nil, err
+	}
+
+	return s.Client.GetObject(context.Background(), s.Bucket, key, minio.GetObjectOptions{})
+}
+
+func (s *MediaStoreS3) Delete(key string) error {
+	return s.Client.RemoveObject(context.Background(), s.Bucket, key, minio.RemoveObjectOptions{})
+}
+
+func (s *MediaStoreS3) PresignURL(key string, mimeType string, fileName string) (string, error) {
+	// Always Serve Media as Download With Its Real MIME Type
+	// So Sender Controlled Content is Not Rendered by Browser
+	reqParams := make(url.Values)
+	reqParams.Set("response-content-type", mimeType)
+
+	if len(fileName) > 0 {
+		reqParams.Set("response-content-disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	} else {
+		reqParams.Set("response-content-disposition", "attachment")
+	}
+
+	presignedURL, err := s.Client.PresignedGetObject(context.Background(), s.Bucket, key, s.PresignExpiry, reqParams)
+	if err != nil {
+		return "", err
+	}
+
+	return presignedURL.String(), nil
+}
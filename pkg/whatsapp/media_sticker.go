@@ -0,0 +1,321 @@
+package whatsapp
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/draw"
+
+	webp "github.com/nickalie/go-webpbin"
+	"github.com/sunshineplan/imgconv"
+
+	"github.com/dimaskiddo/go-whatsapp-multidevice-rest/pkg/env"
+)
+
+type StickerWebP struct {
+	Bytes      []byte
+	Width      uint32
+	Height     uint32
+	IsAnimated bool
+}
+
+type webpChunk struct {
+	FourCC string
+	Data   []byte
+}
+
+var (
+	WhatsAppStickerPackName      string
+	WhatsAppStickerPackPublisher string
+)
+
+const (
+	whatsAppStickerSize             = 512
+	whatsAppStickerAnimatedMaxSize  = 500 * 1024
+	whatsAppStickerAnimatedMaxFrame = 300
+	whatsAppStickerAnimatedMaxMS    = 10 * 1000
+)
+
+const (
+	webpFlagAnimation = 0x02
+	webpFlagEXIF      = 0x08
+	webpFlagAlpha     = 0x10
+)
+
+func init() {
+	var err error
+
+	// Empty Sticker Pack Name and Publisher Means
+	// Sticker is Sent Without Sticker Pack Metadata
+	WhatsAppStickerPackName, err = env.GetEnvString("WHATSAPP_MEDIA_STICKER_PACK_NAME")
+	if err != nil {
+		WhatsAppStickerPackName = ""
+	}
+
+	WhatsAppStickerPackPublisher, err = env.GetEnvString("WHATSAPP_MEDIA_STICKER_PACK_PUBLISHER")
+	if err != nil {
+		WhatsAppStickerPackPublisher = ""
+	}
+}
+
+func WhatsAppStickerConvert(stickerBytes []byte) (StickerWebP, error) {
+	// Animated WebP is Passed Through As Is
+	// Since Re-Encoding Would Flatten it to Single Frame
+	if WhatsAppStickerIsAnimated(stickerBytes) {
+		return WhatsAppStickerValidateAnimated(stickerBytes)
+	}
+
+	stickerConvDecode, err := imgconv.Decode(bytes.NewReader(stickerBytes))
+	if err != nil {
+		return StickerWebP{}, errors.New("Error While Decoding Convert Sticker Stream")
+	}
+
+	// Fit Sticker Inside 512x512 px and Preserve Aspect Ratio
+	// Then Pad The Rest with Transparent Pixel Instead of Stretching
+	stickerResizeOption := imgconv.ResizeOption{Width: whatsAppStickerSize}
+	if stickerConvDecode.Bounds().Dy() > stickerConvDecode.Bounds().Dx() {
+		stickerResizeOption = imgconv.ResizeOption{Height: whatsAppStickerSize}
+	}
+
+	stickerConvResize := imgconv.Resize(stickerConvDecode, stickerResizeOption)
+	stickerConvBounds := stickerConvResize.Bounds()
+
+	stickerConvCanvas := image.NewNRGBA(image.Rect(0, 0, whatsAppStickerSize, whatsAppStickerSize))
+	stickerConvOffset := image.Pt((whatsAppStickerSize-stickerConvBounds.Dx())/2, (whatsAppStickerSize-stickerConvBounds.Dy())/2)
+
+	draw.Draw(stickerConvCanvas, stickerConvBounds.Sub(stickerConvBounds.Min).Add(stickerConvOffset),
+		stickerConvResize, stickerConvBounds.Min, draw.Src)
+
+	stickerConvEncode := new(bytes.Buffer)
+
+	err = webp.Encode(stickerConvEncode, stickerConvCanvas)
+	if err != nil {
+		return StickerWebP{}, errors.New("Error While Encoding Convert Sticker Stream")
+	}
+
+	return StickerWebP{
+		Bytes:  stickerConvEncode.Bytes(),
+		Width:  whatsAppStickerSize,
+		Height: whatsAppStickerSize,
+	}, nil
+}
+
+func WhatsAppStickerIsAnimated(stickerBytes []byte) bool {
+	chunks, err := whatsAppWebPChunks(stickerBytes)
+	if err != nil || len(chunks) == 0 {
+		return false
+	}
+
+	return chunks[0].FourCC == "VP8X" && len(chunks[0].Data) >= 10 && chunks[0].Data[0]&webpFlagAnimation != 0
+}
+
+func WhatsAppStickerValidateAnimated(stickerBytes []byte) (StickerWebP, error) {
+	if len(stickerBytes) > whatsAppStickerAnimatedMaxSize {
+		return StickerWebP{}, errors.New("WhatsApp Animated Sticker Should be Less Than 500 KB")
+	}
+
+	chunks, err := whatsAppWebPChunks(stickerBytes)
+	if err != nil {
+		return StickerWebP{}, err
+	}
+
+	if chunks[0].FourCC != "VP8X" {
+		return StickerWebP{}, errors.New("WhatsApp Animated Sticker is Not Valid Animated WebP Image")
+	}
+
+	// Canvas Width and Height are Stored as 24-bit Minus One
+	width, height := whatsAppWebPCanvasSize(chunks[0].Data)
+	if width != whatsAppStickerSize || height != whatsAppStickerSize {
+		return StickerWebP{}, errors.New("WhatsApp Animated Sticker Should be 512x512 px")
+	}
+
+	// Count Animation Frames and Total Duration
+	// Frame Duration is Stored as 24-bit Milliseconds
+	var frameCount, frameDuration int
+	for _, chunk := range chunks {
+		if chunk.FourCC != "ANMF" || len(chunk.Data) < 16 {
+			continue
+		}
+
+		frameCount++
+		frameDuration += int(whatsAppWebPUint24(chunk.Data[12:15]))
+	}
+
+	if frameCount == 0 {
+		return StickerWebP{}, errors.New("WhatsApp Animated Sticker Has No Frame")
+	}
+
+	if frameCount > whatsAppStickerAnimatedMaxFrame {
+		return StickerWebP{}, errors.New("WhatsApp Animated Sticker Should Have at Most 300 Frames")
+	}
+
+	if frameDuration > whatsAppStickerAnimatedMaxMS {
+		return StickerWebP{}, errors.New("WhatsApp Animated Sticker Should be at Most 10 Seconds")
+	}
+
+	return StickerWebP{
+		Bytes:      stickerBytes,
+		Width:      width,
+		Height:     height,
+		IsAnimated: true,
+	}, nil
+}
+
+func WhatsAppStickerPackExif(packName string, packPublisher string) ([]byte, error) {
+	// Sticker Pack ID is Derived from Name and Publisher
+	// So Stickers with The Same Metadata Belong to The Same Pack
+	packKey, err := json.Marshal([]string{packName, packPublisher})
+	if err != nil {
+		return nil, err
+	}
+
+	packHash := sha256.Sum256(packKey)
+
+	packJSON, err := json.Marshal(map[string]interface{}{
+		"sticker-pack-id":        hex.EncodeToString(packHash[:16]),
+		"sticker-pack-name":      packName,
+		"sticker-pack-publisher": packPublisher,
+		"emojis":                 []string{},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Little Endian TIFF Header with Single IFD Entry
+	// Tag 0x5741 with Undefined Type Pointing to JSON Metadata
+	exif := []byte{
+		0x49, 0x49, 0x2A, 0x00, 0x08, 0x00, 0x00, 0x00,
+		0x01, 0x00, 0x41, 0x57, 0x07, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x16, 0x00, 0x00, 0x00,
+	}
+	binary.LittleEndian.PutUint32(exif[14:18], uint32(len(packJSON)))
+
+	return append(exif, packJSON...), nil
+}
+
+func WhatsAppStickerSetExif(sticker StickerWebP, exif []byte) (StickerWebP, error) {
+	chunks, err := whatsAppWebPChunks(sticker.Bytes)
+	if err != nil {
+		return sticker, err
+	}
+
+	// Extended Format Header is Required to Carry EXIF Chunk
+	// So Create One When Sticker is Simple Lossy or Lossless WebP
+	if chunks[0].FourCC != "VP8X" {
+		vp8x := make([]byte, 10)
+		for _, chunk := range chunks {
+			if chunk.FourCC == "VP8L" || chunk.FourCC == "ALPH" {
+				vp8x[0] |= webpFlagAlpha
+			}
+		}
+
+		whatsAppWebPPutUint24(vp8x[4:7], sticker.Width-1)
+		whatsAppWebPPutUint24(vp8x[7:10], sticker.Height-1)
+
+		chunks = append([]webpChunk{{FourCC: "VP8X", Data: vp8x}}, chunks...)
+	}
+
+	// Replace Existing EXIF Chunk with Sticker Pack Metadata
+	// EXIF Chunk Must be Placed After Image Data and Before XMP Chunk
+	var stickerChunks, xmpChunks []webpChunk
+	for _, chunk := range chunks {
+		switch chunk.FourCC {
+		case "EXIF":
+			continue
+		case "XMP ":
+			xmpChunks = append(xmpChunks, chunk)
+		default:
+			stickerChunks = append(stickerChunks, chunk)
+		}
+	}
+
+	vp8x := append([]byte{}, stickerChunks[0].Data...)
+	vp8x[0] |= webpFlagEXIF
+	stickerChunks[0].Data = vp8x
+
+	stickerChunks = append(stickerChunks, webpChunk{FourCC: "EXIF", Data: exif})
+	stickerChunks = append(stickerChunks, xmpChunks...)
+
+	sticker.Bytes = whatsAppWebPEncode(stickerChunks)
+
+	return sticker, nil
+}
+
+func whatsAppWebPChunks(data []byte) ([]webpChunk, error) {
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, errors.New("WhatsApp Sticker is Not Valid WebP Image")
+	}
+
+	riffSize := int(binary.LittleEndian.Uint32(data[4:8])) + 8
+	if riffSize > len(data) {
+		riffSize = len(data)
+	}
+
+	var chunks []webpChunk
+	for offset := 12; offset+8 <= riffSize; {
+		chunkSize := int(binary.LittleEndian.Uint32(data[offset+4 : offset+8]))
+		if chunkSize < 0 || offset+8+chunkSize > riffSize {
+			return nil, errors.New("WhatsApp Sticker is Not Valid WebP Image")
+		}
+
+		chunks = append(chunks, webpChunk{
+			FourCC: string(data[offset : offset+4]),
+			Data:   data[offset+8 : offset+8+chunkSize],
+		})
+
+		// Chunk Data is Padded to Even Size
+		offset += 8 + chunkSize + chunkSize%2
+	}
+
+	if len(chunks) == 0 {
+		return nil, errors.New("WhatsApp Sticker is Not Valid WebP Image")
+	}
+
+	return chunks, nil
+}
+
+func whatsAppWebPEncode(chunks []webpChunk) []byte {
+	body := new(bytes.Buffer)
+	body.WriteString("WEBP")
+
+	for _, chunk := range chunks {
+		chunkHeader := make([]byte, 8)
+		copy(chunkHeader[0:4], chunk.FourCC)
+		binary.LittleEndian.PutUint32(chunkHeader[4:8], uint32(len(chunk.Data)))
+
+		body.Write(chunkHeader)
+		body.Write(chunk.Data)
+
+		if len(chunk.Data)%2 == 1 {
+			body.WriteByte(0)
+		}
+	}
+
+	riffHeader := make([]byte, 8)
+	copy(riffHeader[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(riffHeader[4:8], uint32(body.Len()))
+
+	return append(riffHeader, body.Bytes()...)
+}
+
+func whatsAppWebPCanvasSize(vp8x []byte) (uint32, uint32) {
+	if len(vp8x) < 10 {
+		return 0, 0
+	}
+
+	return whatsAppWebPUint24(vp8x[4:7]) + 1, whatsAppWebPUint24(vp8x[7:10]) + 1
+}
+
+func whatsAppWebPUint24(b []byte) uint32 {
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16
+}
+
+func whatsAppWebPPutUint24(b []byte, v uint32) {
+	b[0] = byte(v)
+	b[1] = byte(v >> 8)
+	b[2] = byte(v >> 16)
+}
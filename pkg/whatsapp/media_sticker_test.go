@@ -0,0 +1,271 @@
+package whatsapp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+)
+
+func testWebPChunk(fourCC string, data []byte) []byte {
+	chunk := make([]byte, 8)
+	copy(chunk[0:4], fourCC)
+	binary.LittleEndian.PutUint32(chunk[4:8], uint32(len(data)))
+
+	chunk = append(chunk, data...)
+	if len(data)%2 == 1 {
+		chunk = append(chunk, 0)
+	}
+
+	return chunk
+}
+
+func testWebP(chunks ...[]byte) []byte {
+	body := append([]byte("WEBP"), bytes.Join(chunks, nil)...)
+
+	riff := make([]byte, 8)
+	copy(riff[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(riff[4:8], uint32(len(body)))
+
+	return append(riff, body...)
+}
+
+func TestWhatsAppWebPChunks(t *testing.T) {
+	vp8 := testWebPChunk("VP8 ", []byte("even"))
+	odd := testWebPChunk("EXIF", []byte("odd"))
+
+	// Last Odd Sized Chunk Without Padding Byte
+	unpadded := testWebP(vp8, odd)
+	unpadded = unpadded[:len(unpadded)-1]
+	binary.LittleEndian.PutUint32(unpadded[4:8], uint32(len(unpadded)-8))
+
+	oversizedChunk := testWebP(vp8, odd)
+	binary.LittleEndian.PutUint32(oversizedChunk[28:32], 0xFFFFFFFF)
+
+	oversizedRIFF := testWebP(vp8, odd)
+	binary.LittleEndian.PutUint32(oversizedRIFF[4:8], 0xFFFFFFFF)
+
+	undersizedRIFF := testWebP(vp8, odd)
+	binary.LittleEndian.PutUint32(undersizedRIFF[4:8], uint32(4+len(vp8)))
+
+	notWebP := testWebP(vp8)
+	copy(notWebP[8:12], "WAVE")
+
+	tests := []struct {
+		name    string
+		data    []byte
+		fourCCs []string
+		sizes   []int
+		wantErr bool
+	}{
+		{name: "Even Sized Chunks", data: testWebP(vp8, vp8), fourCCs: []string{"VP8 ", "VP8 "}, sizes: []int{4, 4}},
+		{name: "Odd Sized Chunk Padding", data: testWebP(odd, vp8), fourCCs: []string{"EXIF", "VP8 "}, sizes: []int{3, 4}},
+		{name: "Odd Sized Last Chunk Without Padding", data: unpadded, fourCCs: []string{"VP8 ", "EXIF"}, sizes: []int{4, 3}},
+		{name: "Empty Chunk", data: testWebP(testWebPChunk("VP8X", nil)), fourCCs: []string{"VP8X"}, sizes: []int{0}},
+		{name: "RIFF Size Larger Than Data", data: oversizedRIFF, fourCCs: []string{"VP8 ", "EXIF"}, sizes: []int{4, 3}},
+		{name: "RIFF Size Excludes Trailing Chunk", data: undersizedRIFF, fourCCs: []string{"VP8 "}, sizes: []int{4}},
+		{name: "Trailing Partial Chunk Header", data: append(testWebP(vp8), 'X', 'M'), fourCCs: []string{"VP8 "}, sizes: []int{4}},
+		{name: "Oversized Chunk Size", data: oversizedChunk, wantErr: true},
+		{name: "Truncated Chunk Data", data: testWebP(vp8, odd)[:len(testWebP(vp8, odd))-2], wantErr: true},
+		{name: "No Chunk", data: testWebP(), wantErr: true},
+		{name: "Truncated Header", data: testWebP(vp8)[:11], wantErr: true},
+		{name: "Not WebP", data: notWebP, wantErr: true},
+		{name: "Empty", data: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks, err := whatsAppWebPChunks(tt.data)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(chunks) != len(tt.fourCCs) {
+				t.Fatalf("got %d chunks, want %d", len(chunks), len(tt.fourCCs))
+			}
+
+			for i, chunk := range chunks {
+				if chunk.FourCC != tt.fourCCs[i] || len(chunk.Data) != tt.sizes[i] {
+					t.Fatalf("chunk %d got (%q, %d), want (%q, %d)", i, chunk.FourCC, len(chunk.Data), tt.fourCCs[i], tt.sizes[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWhatsAppWebPEncode(t *testing.T) {
+	chunks := []webpChunk{
+		{FourCC: "VP8X", Data: make([]byte, 10)},
+		{FourCC: "VP8L", Data: []byte("odd")},
+		{FourCC: "EXIF", Data: []byte("exif")},
+	}
+
+	decoded, err := whatsAppWebPChunks(whatsAppWebPEncode(chunks))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(decoded) != len(chunks) {
+		t.Fatalf("got %d chunks, want %d", len(decoded), len(chunks))
+	}
+
+	for i := range chunks {
+		if decoded[i].FourCC != chunks[i].FourCC || !bytes.Equal(decoded[i].Data, chunks[i].Data) {
+			t.Fatalf("chunk %d got (%q, %q), want (%q, %q)", i, decoded[i].FourCC, decoded[i].Data, chunks[i].FourCC, chunks[i].Data)
+		}
+	}
+}
+
+func TestWhatsAppStickerPackExif(t *testing.T) {
+	tests := []struct {
+		name      string
+		packName  string
+		publisher string
+	}{
+		{name: "Regular", packName: "My Pack", publisher: "Me"},
+		{name: "Empty", packName: "", publisher: ""},
+		{name: "Escaped Characters", packName: "Pack \"Quoted\"\n", publisher: "Üñíçødé 😀"},
+		{name: "Long Name", packName: string(bytes.Repeat([]byte("a"), 70000)), publisher: "Me"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exif, err := WhatsAppStickerPackExif(tt.packName, tt.publisher)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(exif) < 22 || !bytes.Equal(exif[0:4], []byte{0x49, 0x49, 0x2A, 0x00}) {
+				t.Fatalf("EXIF header is not little endian TIFF: %x", exif[:8])
+			}
+
+			if binary.LittleEndian.Uint32(exif[4:8]) != 8 || binary.LittleEndian.Uint16(exif[8:10]) != 1 {
+				t.Fatalf("EXIF IFD offset or entry count is not valid: %x", exif[4:10])
+			}
+
+			if binary.LittleEndian.Uint16(exif[10:12]) != 0x5741 || binary.LittleEndian.Uint16(exif[12:14]) != 7 {
+				t.Fatalf("EXIF entry tag or type is not valid: %x", exif[10:14])
+			}
+
+			jsonLength := binary.LittleEndian.Uint32(exif[14:18])
+			jsonOffset := binary.LittleEndian.Uint32(exif[18:22])
+			if int(jsonOffset)+int(jsonLength) != len(exif) {
+				t.Fatalf("EXIF JSON offset %d and length %d do not match size %d", jsonOffset, jsonLength, len(exif))
+			}
+
+			var pack map[string]interface{}
+			err = json.Unmarshal(exif[jsonOffset:], &pack)
+			if err != nil {
+				t.Fatalf("EXIF JSON is not valid: %v", err)
+			}
+
+			if pack["sticker-pack-name"] != tt.packName || pack["sticker-pack-publisher"] != tt.publisher {
+				t.Fatalf("got (%q, %q), want (%q, %q)", pack["sticker-pack-name"], pack["sticker-pack-publisher"], tt.packName, tt.publisher)
+			}
+
+			packID, _ := pack["sticker-pack-id"].(string)
+			if len(packID) != 32 {
+				t.Fatalf("sticker pack id %q is not 16 bytes hex", packID)
+			}
+		})
+	}
+}
+
+func TestWhatsAppStickerPackExifID(t *testing.T) {
+	packID := func(packName string, packPublisher string) string {
+		exif, err := WhatsAppStickerPackExif(packName, packPublisher)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var pack map[string]interface{}
+		_ = json.Unmarshal(exif[22:], &pack)
+
+		return pack["sticker-pack-id"].(string)
+	}
+
+	if packID("Pack", "Me") != packID("Pack", "Me") {
+		t.Fatal("same sticker pack metadata should have same pack id")
+	}
+
+	if packID("Pack", "Me") == packID("Pack", "You") {
+		t.Fatal("different sticker pack publisher should have different pack id")
+	}
+
+	if packID("a\nb", "c") == packID("a", "b\nc") {
+		t.Fatal("sticker pack name and publisher boundary should change pack id")
+	}
+}
+
+func TestWhatsAppStickerSetExif(t *testing.T) {
+	exif := []byte("exif-odd")[:7]
+
+	vp8x := make([]byte, 10)
+	whatsAppWebPPutUint24(vp8x[4:7], 511)
+	whatsAppWebPPutUint24(vp8x[7:10], 511)
+
+	tests := []struct {
+		name    string
+		sticker StickerWebP
+		fourCCs []string
+	}{
+		{
+			name:    "Simple Lossy",
+			sticker: StickerWebP{Bytes: testWebP(testWebPChunk("VP8 ", []byte("lossy"))), Width: 512, Height: 512},
+			fourCCs: []string{"VP8X", "VP8 ", "EXIF"},
+		},
+		{
+			name: "Extended With Existing EXIF and XMP",
+			sticker: StickerWebP{Bytes: testWebP(
+				testWebPChunk("VP8X", vp8x),
+				testWebPChunk("VP8L", []byte("lossless")),
+				testWebPChunk("EXIF", []byte("old")),
+				testWebPChunk("XMP ", []byte("xmp"))), Width: 512, Height: 512},
+			fourCCs: []string{"VP8X", "VP8L", "EXIF", "XMP "},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sticker, err := WhatsAppStickerSetExif(tt.sticker, exif)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			chunks, err := whatsAppWebPChunks(sticker.Bytes)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(chunks) != len(tt.fourCCs) {
+				t.Fatalf("got %d chunks, want %d", len(chunks), len(tt.fourCCs))
+			}
+
+			for i, chunk := range chunks {
+				if chunk.FourCC != tt.fourCCs[i] {
+					t.Fatalf("chunk %d got %q, want %q", i, chunk.FourCC, tt.fourCCs[i])
+				}
+
+				if chunk.FourCC == "EXIF" && !bytes.Equal(chunk.Data, exif) {
+					t.Fatalf("EXIF chunk got %q, want %q", chunk.Data, exif)
+				}
+			}
+
+			if chunks[0].Data[0]&webpFlagEXIF == 0 {
+				t.Fatal("VP8X EXIF flag is not set")
+			}
+
+			width, height := whatsAppWebPCanvasSize(chunks[0].Data)
+			if width != 512 || height != 512 {
+				t.Fatalf("got canvas %dx%d, want 512x512", width, height)
+			}
+		})
+	}
+}
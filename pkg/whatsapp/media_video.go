@@ -0,0 +1,256 @@
+package whatsapp
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"strings"
+
+	"github.com/sunshineplan/imgconv"
+	waproto "go.mau.fi/whatsmeow/binary/proto"
+)
+
+type VideoMetadata struct {
+	Seconds uint32
+	Width   uint32
+	Height  uint32
+}
+
+type VideoGIF struct {
+	Attribution waproto.VideoMessage_Attribution
+}
+
+const whatsAppVideoGIFMaxSeconds = 30
+
+type mp4Box struct {
+	Type string
+	Data []byte
+}
+
+func WhatsAppVideoMetadata(videoBytes []byte) (VideoMetadata, error) {
+	var metadata VideoMetadata
+
+	// Movie Box Contains Duration and Track Information
+	moov, ok := whatsAppMP4FindBox(videoBytes, "moov")
+	if !ok {
+		return metadata, errors.New("WhatsApp Video is Not Valid MP4 Video")
+	}
+
+	// Movie Header Box Contains Time Scale and Duration
+	mvhd, ok := whatsAppMP4FindBox(moov, "mvhd")
+	if ok && len(mvhd) >= 20 {
+		var timeScale uint32
+		var duration uint64
+
+		// Version 1 Movie Header Has 64-bit Times
+		// So Truncated Version 1 Box Should Not be Read as Version 0
+		if mvhd[0] == 1 {
+			if len(mvhd) >= 32 {
+				timeScale = binary.BigEndian.Uint32(mvhd[20:24])
+				duration = binary.BigEndian.Uint64(mvhd[24:32])
+			}
+		} else {
+			timeScale = binary.BigEndian.Uint32(mvhd[12:16])
+			duration = uint64(binary.BigEndian.Uint32(mvhd[16:20]))
+		}
+
+		if timeScale > 0 {
+			metadata.Seconds = uint32(math.Round(float64(duration) / float64(timeScale)))
+		}
+	}
+
+	// Get Dimensions from Track Header Box of Video Track
+	for _, trak := range whatsAppMP4Boxes(moov) {
+		if trak.Type != "trak" {
+			continue
+		}
+
+		mdia, ok := whatsAppMP4FindBox(trak.Data, "mdia")
+		if !ok {
+			continue
+		}
+
+		hdlr, ok := whatsAppMP4FindBox(mdia, "hdlr")
+		if !ok || len(hdlr) < 12 || string(hdlr[8:12]) != "vide" {
+			continue
+		}
+
+		tkhd, ok := whatsAppMP4FindBox(trak.Data, "tkhd")
+		if !ok || len(tkhd) < 84 {
+			continue
+		}
+
+		// Version 1 Track Header Has 64-bit Times
+		// Matrix and Dimensions are at The End of The Box
+		offset := 0
+		if tkhd[0] == 1 {
+			offset = 12
+		}
+
+		if len(tkhd) < 84+offset {
+			continue
+		}
+
+		matrix := tkhd[40+offset : 76+offset]
+		metadata.Width = binary.BigEndian.Uint32(tkhd[76+offset:80+offset]) >> 16
+		metadata.Height = binary.BigEndian.Uint32(tkhd[80+offset:84+offset]) >> 16
+
+		// Swap Dimensions When Video is Rotated 90 or 270 Degree
+		if binary.BigEndian.Uint32(matrix[0:4]) == 0 && binary.BigEndian.Uint32(matrix[16:20]) == 0 {
+			metadata.Width, metadata.Height = metadata.Height, metadata.Width
+		}
+
+		break
+	}
+
+	return metadata, nil
+}
+
+func WhatsAppVideoThumbnail(ctx context.Context, videoBytes []byte, seconds uint32) ([]byte, error) {
+	// Take Poster Frame After First Second
+	// Since The First Frame is Often Black
+	position := "0"
+	if seconds > 1 {
+		position = "1"
+	}
+
+	// Extract Poster Frame Using FFmpeg
+	frameBytes, err := WhatsAppMediaFFmpeg(ctx, videoBytes, whatsAppMediaFFmpegFormatVideo,
+		"-ss", position, "-frames:v", "1", "-f", "image2", "-c:v", "png")
+	if err != nil {
+		return nil, err
+	}
+
+	// Creating Video JPEG Thumbnail
+	// With Permanent Width 72px and Preserve Aspect Ratio
+	frameDecode, err := imgconv.Decode(bytes.NewReader(frameBytes))
+	if err != nil {
+		return nil, errors.New("Error While Decoding Thumbnail Video Stream")
+	}
+
+	frameEncode := new(bytes.Buffer)
+
+	err = imgconv.Write(frameEncode,
+		imgconv.Resize(frameDecode, imgconv.ResizeOption{Width: 72}),
+		imgconv.FormatOption{Format: imgconv.JPEG})
+	if err != nil {
+		return nil, errors.New("Error While Encoding Thumbnail Video Stream")
+	}
+
+	return frameEncode.Bytes(), nil
+}
+
+func WhatsAppVideoFromGIF(ctx context.Context, gifBytes []byte) ([]byte, error) {
+	// WhatsApp Plays GIF as Looping Muted H.264 MP4 Video
+	// Dimensions Must be Even Numbers for YUV 4:2:0 Pixel Format
+	videoBytes, err := WhatsAppMediaFFmpegFile(ctx, gifBytes, whatsAppMediaFFmpegFormatGIF,
+		"-an", "-c:v", "libx264", "-pix_fmt", "yuv420p", "-vf", "scale=trunc(iw/2)*2:trunc(ih/2)*2",
+		"-movflags", "+faststart", "-f", "mp4")
+	if err != nil {
+		return nil, errors.New("Error While Converting GIF to MP4 Video, " + err.Error())
+	}
+
+	return videoBytes, nil
+}
+
+func WhatsAppVideoGIF(ctx context.Context, gifBytes []byte, gifType string) ([]byte, error) {
+	var videoBytes []byte
+	var err error
+
+	if gifType == "image/gif" || bytes.HasPrefix(gifBytes, []byte("GIF8")) {
+		// WhatsApp Cannot Play GIF Image Directly
+		// So Convert it to MP4 Video
+		videoBytes, err = WhatsAppVideoFromGIF(ctx, gifBytes)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Make Sure Input is MP4 Video Before Processing it
+		_, err = WhatsAppVideoMetadata(gifBytes)
+		if err != nil {
+			return nil, errors.New("WhatsApp GIF Should be GIF Image or MP4 Video")
+		}
+
+		// GIF Playback is Always Muted
+		// So Strip Audio Track from MP4 Video Without Re-Encoding
+		videoBytes, err = WhatsAppMediaFFmpegFile(ctx, gifBytes, whatsAppMediaFFmpegFormatVideo,
+			"-an", "-c:v", "copy", "-movflags", "+faststart", "-f", "mp4")
+		if err != nil {
+			return nil, errors.New("Error While Removing Audio from MP4 Video, " + err.Error())
+		}
+	}
+
+	videoMetadata, err := WhatsAppVideoMetadata(videoBytes)
+	if err != nil {
+		return nil, errors.New("WhatsApp GIF Should be GIF Image or MP4 Video")
+	}
+
+	if videoMetadata.Seconds > whatsAppVideoGIFMaxSeconds {
+		return nil, errors.New("WhatsApp GIF Should be at Most 30 Seconds")
+	}
+
+	return videoBytes, nil
+}
+
+func WhatsAppVideoGIFAttribution(attribution string) (waproto.VideoMessage_Attribution, error) {
+	switch strings.ToLower(strings.TrimSpace(attribution)) {
+	case "", "none":
+		return waproto.VideoMessage_NONE, nil
+	case "giphy":
+		return waproto.VideoMessage_GIPHY, nil
+	case "tenor":
+		return waproto.VideoMessage_TENOR, nil
+	default:
+		return waproto.VideoMessage_NONE, errors.New("WhatsApp GIF Attribution Should be None, Giphy or Tenor")
+	}
+}
+
+func whatsAppMP4Boxes(data []byte) []mp4Box {
+	var boxes []mp4Box
+
+	for offset := 0; offset+8 <= len(data); {
+		boxSize := uint64(binary.BigEndian.Uint32(data[offset : offset+4]))
+		boxType := string(data[offset+4 : offset+8])
+		headerSize := uint64(8)
+
+		switch boxSize {
+		case 0:
+			// Box Extends to The End of Data
+			boxSize = uint64(len(data) - offset)
+
+		case 1:
+			// Box Size is Written as 64-bit Large Size
+			if offset+16 > len(data) {
+				return boxes
+			}
+
+			boxSize = binary.BigEndian.Uint64(data[offset+8 : offset+16])
+			headerSize = 16
+		}
+
+		if boxSize < headerSize || boxSize > uint64(len(data)-offset) {
+			return boxes
+		}
+
+		boxes = append(boxes, mp4Box{
+			Type: boxType,
+			Data: data[offset+int(headerSize) : offset+int(boxSize)],
+		})
+
+		offset += int(boxSize)
+	}
+
+	return boxes
+}
+
+func whatsAppMP4FindBox(data []byte, boxType string) ([]byte, bool) {
+	for _, box := range whatsAppMP4Boxes(data) {
+		if box.Type == boxType {
+			return box.Data, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,233 @@
+package whatsapp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func testMP4Box(boxType string, data ...[]byte) []byte {
+	body := bytes.Join(data, nil)
+
+	box := make([]byte, 8)
+	binary.BigEndian.PutUint32(box[0:4], uint32(8+len(body)))
+	copy(box[4:8], boxType)
+
+	return append(box, body...)
+}
+
+func testMP4LargeBox(boxType string, body []byte) []byte {
+	box := make([]byte, 16)
+	binary.BigEndian.PutUint32(box[0:4], 1)
+	copy(box[4:8], boxType)
+	binary.BigEndian.PutUint64(box[8:16], uint64(16+len(body)))
+
+	return append(box, body...)
+}
+
+func testMP4Mvhd(version byte, timeScale uint32, duration uint64) []byte {
+	if version == 1 {
+		mvhd := make([]byte, 112)
+		mvhd[0] = 1
+		binary.BigEndian.PutUint32(mvhd[20:24], timeScale)
+		binary.BigEndian.PutUint64(mvhd[24:32], duration)
+		return testMP4Box("mvhd", mvhd)
+	}
+
+	mvhd := make([]byte, 100)
+	binary.BigEndian.PutUint32(mvhd[12:16], timeScale)
+	binary.BigEndian.PutUint32(mvhd[16:20], uint32(duration))
+	return testMP4Box("mvhd", mvhd)
+}
+
+func testMP4Tkhd(version byte, width uint32, height uint32, rotated bool) []byte {
+	offset := 0
+	if version == 1 {
+		offset = 12
+	}
+
+	tkhd := make([]byte, 84+offset)
+	tkhd[0] = version
+
+	// Identity or 90 Degree Rotation Matrix in 16.16 Fixed Point
+	matrix := tkhd[40+offset : 76+offset]
+	if rotated {
+		binary.BigEndian.PutUint32(matrix[4:8], 0x00010000)
+		binary.BigEndian.PutUint32(matrix[12:16], 0xFFFF0000)
+	} else {
+		binary.BigEndian.PutUint32(matrix[0:4], 0x00010000)
+		binary.BigEndian.PutUint32(matrix[16:20], 0x00010000)
+	}
+	binary.BigEndian.PutUint32(matrix[32:36], 0x40000000)
+
+	binary.BigEndian.PutUint32(tkhd[76+offset:80+offset], width<<16)
+	binary.BigEndian.PutUint32(tkhd[80+offset:84+offset], height<<16)
+
+	return testMP4Box("tkhd", tkhd)
+}
+
+func testMP4Trak(handler string, tkhd []byte) []byte {
+	hdlr := make([]byte, 24)
+	copy(hdlr[8:12], handler)
+
+	return testMP4Box("trak", tkhd, testMP4Box("mdia", testMP4Box("hdlr", hdlr)))
+}
+
+func TestWhatsAppMP4Boxes(t *testing.T) {
+	ftyp := testMP4Box("ftyp", []byte("isom"))
+	free := testMP4Box("free")
+
+	oversized := testMP4Box("mdat", []byte("data"))
+	binary.BigEndian.PutUint32(oversized[0:4], 0xFFFFFFFF)
+
+	undersized := testMP4Box("mdat", []byte("data"))
+	binary.BigEndian.PutUint32(undersized[0:4], 4)
+
+	largeOversized := testMP4LargeBox("mdat", []byte("data"))
+	binary.BigEndian.PutUint64(largeOversized[8:16], 1<<63)
+
+	largeUndersized := testMP4LargeBox("mdat", []byte("data"))
+	binary.BigEndian.PutUint64(largeUndersized[8:16], 12)
+
+	tests := []struct {
+		name  string
+		data  []byte
+		types []string
+		sizes []int
+	}{
+		{name: "Empty", data: nil},
+		{name: "Sequential", data: bytes.Join([][]byte{ftyp, free}, nil), types: []string{"ftyp", "free"}, sizes: []int{4, 0}},
+		{name: "Large Size", data: append(testMP4LargeBox("mdat", []byte("data")), free...), types: []string{"mdat", "free"}, sizes: []int{4, 0}},
+		{name: "Extends to End", data: append(append([]byte{}, ftyp...), 0, 0, 0, 0, 'm', 'd', 'a', 't', 1, 2, 3), types: []string{"ftyp", "mdat"}, sizes: []int{4, 3}},
+		{name: "Truncated Header", data: append(append([]byte{}, ftyp...), free[:7]...), types: []string{"ftyp"}, sizes: []int{4}},
+		{name: "Truncated Body", data: append(append([]byte{}, ftyp...), testMP4Box("moov", []byte("data"))[:10]...), types: []string{"ftyp"}, sizes: []int{4}},
+		{name: "Oversized Size", data: append(append([]byte{}, ftyp...), oversized...), types: []string{"ftyp"}, sizes: []int{4}},
+		{name: "Undersized Size", data: append(append([]byte{}, ftyp...), undersized...), types: []string{"ftyp"}, sizes: []int{4}},
+		{name: "Truncated Large Size", data: testMP4LargeBox("mdat", nil)[:12]},
+		{name: "Oversized Large Size", data: largeOversized},
+		{name: "Undersized Large Size", data: largeUndersized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			boxes := whatsAppMP4Boxes(tt.data)
+			if len(boxes) != len(tt.types) {
+				t.Fatalf("got %d boxes, want %d", len(boxes), len(tt.types))
+			}
+
+			for i, box := range boxes {
+				if box.Type != tt.types[i] || len(box.Data) != tt.sizes[i] {
+					t.Fatalf("box %d got (%s, %d), want (%s, %d)", i, box.Type, len(box.Data), tt.types[i], tt.sizes[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWhatsAppVideoMetadata(t *testing.T) {
+	ftyp := testMP4Box("ftyp", []byte("isom"))
+
+	// Modification Time of Version 1 Movie Header Overlaps
+	// With Time Scale and Duration Offsets of Version 0
+	truncatedMvhd := make([]byte, 31)
+	truncatedMvhd[0] = 1
+	binary.BigEndian.PutUint32(truncatedMvhd[12:16], 1000)
+	binary.BigEndian.PutUint32(truncatedMvhd[16:20], 5000)
+
+	video := func(boxes ...[]byte) []byte {
+		return append(append([]byte{}, ftyp...), testMP4Box("moov", boxes...)...)
+	}
+
+	tests := []struct {
+		name     string
+		data     []byte
+		metadata VideoMetadata
+		wantErr  bool
+	}{
+		{
+			name:     "Version 0",
+			data:     video(testMP4Mvhd(0, 1000, 5400), testMP4Trak("vide", testMP4Tkhd(0, 1280, 720, false))),
+			metadata: VideoMetadata{Seconds: 5, Width: 1280, Height: 720},
+		},
+		{
+			name:     "Version 1",
+			data:     video(testMP4Mvhd(1, 90000, 90000*61), testMP4Trak("vide", testMP4Tkhd(1, 1920, 1080, false))),
+			metadata: VideoMetadata{Seconds: 61, Width: 1920, Height: 1080},
+		},
+		{
+			name:     "Rotated",
+			data:     video(testMP4Mvhd(0, 600, 1800), testMP4Trak("vide", testMP4Tkhd(0, 1920, 1080, true))),
+			metadata: VideoMetadata{Seconds: 3, Width: 1080, Height: 1920},
+		},
+		{
+			name:     "Audio Track Before Video Track",
+			data:     video(testMP4Mvhd(0, 1000, 2000), testMP4Trak("soun", testMP4Tkhd(0, 0, 0, false)), testMP4Trak("vide", testMP4Tkhd(0, 640, 480, false))),
+			metadata: VideoMetadata{Seconds: 2, Width: 640, Height: 480},
+		},
+		{
+			name:     "Zero Time Scale",
+			data:     video(testMP4Mvhd(0, 0, 5000), testMP4Trak("vide", testMP4Tkhd(0, 640, 480, false))),
+			metadata: VideoMetadata{Seconds: 0, Width: 640, Height: 480},
+		},
+		{
+			name:     "Truncated Version 0 Movie Header",
+			data:     video(testMP4Box("mvhd", make([]byte, 19)), testMP4Trak("vide", testMP4Tkhd(0, 640, 480, false))),
+			metadata: VideoMetadata{Seconds: 0, Width: 640, Height: 480},
+		},
+		{
+			name:     "Truncated Version 1 Movie Header",
+			data:     video(testMP4Box("mvhd", truncatedMvhd), testMP4Trak("vide", testMP4Tkhd(0, 640, 480, false))),
+			metadata: VideoMetadata{Seconds: 0, Width: 640, Height: 480},
+		},
+		{
+			name:     "Truncated Version 0 Track Header",
+			data:     video(testMP4Mvhd(0, 1000, 1000), testMP4Trak("vide", testMP4Box("tkhd", make([]byte, 83)))),
+			metadata: VideoMetadata{Seconds: 1},
+		},
+		{
+			name:     "Truncated Version 1 Track Header",
+			data:     video(testMP4Mvhd(0, 1000, 1000), testMP4Trak("vide", testMP4Box("tkhd", append([]byte{1}, make([]byte, 94)...)))),
+			metadata: VideoMetadata{Seconds: 1},
+		},
+		{
+			name:     "Missing Handler",
+			data:     video(testMP4Mvhd(0, 1000, 1000), testMP4Box("trak", testMP4Tkhd(0, 640, 480, false))),
+			metadata: VideoMetadata{Seconds: 1},
+		},
+		{
+			name:    "Missing Movie Box",
+			data:    append(append([]byte{}, ftyp...), testMP4Box("mdat", []byte("data"))...),
+			wantErr: true,
+		},
+		{
+			name:    "Truncated Movie Box",
+			data:    video(testMP4Mvhd(0, 1000, 1000))[:20],
+			wantErr: true,
+		},
+		{
+			name:    "Empty",
+			data:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata, err := WhatsAppVideoMetadata(tt.data)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if metadata != tt.metadata {
+				t.Fatalf("got %+v, want %+v", metadata, tt.metadata)
+			}
+		})
+	}
+}
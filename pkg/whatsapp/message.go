@@ -0,0 +1,433 @@
+package whatsapp
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waproto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/dimaskiddo/go-whatsapp-multidevice-rest/pkg/log"
+)
+
+type ArchivedMessage struct {
+	ID        string             `json:"id"`
+	ChatJID   string             `json:"chat_jid"`
+	SenderJID string             `json:"sender_jid"`
+	Direction string             `json:"direction"`
+	Type      string             `json:"type"`
+	Text      string             `json:"text,omitempty"`
+	Media     *EventMessageMedia `json:"media,omitempty"`
+	Edits     []MessageEdit      `json:"edits,omitempty"`
+	Timestamp int64              `json:"timestamp"`
+}
+
+type MessageEdit struct {
+	Text       string `json:"text"`
+	ReplacedAt int64  `json:"replaced_at"`
+}
+
+type MessageFilter struct {
+	ChatJID string
+	Type    string
+	Since   int64
+	Until   int64
+	Limit   int
+	Cursor  string
+}
+
+type MessagePage struct {
+	Messages   []ArchivedMessage `json:"messages"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+const (
+	MessageDirectionIncoming = "incoming"
+	MessageDirectionOutgoing = "outgoing"
+)
+
+var ErrMessageNotFound = errors.New("WhatsApp Message is Not Found")
+
+const (
+	whatsAppMessageLimitDefault = 50
+	whatsAppMessageLimitMax     = 500
+)
+
+func WhatsAppSendMessage(ctx context.Context, jid string, rjid types.JID, msgContent *waproto.Message, msgExtra whatsmeow.SendRequestExtra, msgOptions SendOptions) error {
+	client := WhatsAppClient.Get(jid)
+	if client == nil {
+		return errors.New("WhatsApp Client is not Valid")
+	}
+
+	// Apply Send Options Like Reply Context
+	err := WhatsAppApplySendOptions(jid, rjid, msgContent, msgOptions)
+	if err != nil {
+		return err
+	}
+
+	// Send WhatsApp Message Proto
+	resp, err := client.SendMessage(ctx, rjid, msgContent, msgExtra)
+	if err != nil {
+		return err
+	}
+
+	// Mask JID for Logging Information
+	maskJID := jid[0:len(jid)-4] + "xxxx"
+
+	// Record Server Acknowledgement of Sent Message
+	err = WhatsAppReceiptRecord(jid, msgExtra.ID, "", MessageStatusServerAck, resp.Timestamp.Unix())
+	if err != nil {
+		log.Print(nil).Error("Failed to Record Message Receipt for " + maskJID + ", " + err.Error())
+	}
+
+	// Archive Sent Message
+	// Failed Archive Should Not Fail The Sent Message
+	var sender types.JID
+	if client.Store.ID != nil {
+		sender = client.Store.ID.ToNonAD()
+	}
+
+	err = WhatsAppMessageArchive(jid, &events.Message{
+		Info: types.MessageInfo{
+			MessageSource: types.MessageSource{
+				Chat:     rjid,
+				Sender:   sender,
+				IsFromMe: true,
+				IsGroup:  rjid.Server == types.GroupServer,
+			},
+			ID:        msgExtra.ID,
+			Timestamp: resp.Timestamp,
+		},
+		Message: msgContent,
+	})
+	if err != nil {
+		log.Print(nil).Error("Failed to Archive Sent Message for " + maskJID + ", " + err.Error())
+	}
+
+	return nil
+}
+
+func WhatsAppMessageArchive(jid string, evt *events.Message) error {
+	// Sent Edit is Still Wrapped While Received Edit is Already Unwrapped
+	msgContent := evt.Message
+	if msgContent.GetEditedMessage().GetMessage() != nil {
+		msgContent = msgContent.GetEditedMessage().GetMessage()
+	}
+
+	// Edit Message Updates Archived Message Instead of Archived as New Message
+	msgProtocol := msgContent.GetProtocolMessage()
+	if msgProtocol.GetType() == waproto.ProtocolMessage_MESSAGE_EDIT {
+		editedAt := msgProtocol.GetTimestampMS() / 1000
+		if editedAt == 0 {
+			editedAt = time.Now().Unix()
+		}
+
+		return WhatsAppMessageRecordEdit(jid, msgProtocol.GetKey().GetID(), msgProtocol.GetEditedMessage(), editedAt)
+	}
+
+	msgData := WhatsAppEventComposeMessage(evt)
+
+	// Protocol Messages (Edit, Revoke, Key Share) are Not Archived
+	if msgData.Type == "protocol" {
+		return nil
+	}
+
+	msgDirection := MessageDirectionIncoming
+	if msgData.IsFromMe {
+		msgDirection = MessageDirectionOutgoing
+	}
+
+	var msgMedia EventMessageMedia
+	if msgData.Media != nil {
+		msgMedia = *msgData.Media
+	}
+
+	// Keep Raw Message Proto for Later Use (Quote, Edit, Poll)
+	msgRaw, err := proto.Marshal(evt.Message)
+	if err != nil {
+		return err
+	}
+
+	msgTimestamp := msgData.Timestamp
+	if evt.Info.Timestamp.IsZero() {
+		msgTimestamp = time.Now().Unix()
+	}
+
+	// Save Message to Datastore
+	_, err = WhatsAppDatastoreDB.Exec(`INSERT INTO whatsapp_messages
+		(jid, id, chat_jid, sender_jid, direction, type, text, media_mimetype, media_filename, media_filelength, raw, timestamp)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (jid, id) DO NOTHING`,
+		jid, msgData.ID, msgData.ChatJID, msgData.SenderJID, msgDirection, msgData.Type, msgData.Text,
+		msgMedia.MimeType, msgMedia.FileName, int64(msgMedia.FileLength), base64.StdEncoding.EncodeToString(msgRaw), msgTimestamp)
+
+	return err
+}
+
+func WhatsAppMessageRecordEdit(jid string, id string, msgContent *waproto.Message, editedAt int64) error {
+	var msgType, msgText, msgRaw string
+
+	// Get Current Content of Edited Message
+	row := WhatsAppDatastoreDB.QueryRow(`SELECT type, text, raw FROM whatsapp_messages WHERE jid=$1 AND id=$2`, jid, id)
+
+	err := row.Scan(&msgType, &msgText, &msgRaw)
+	if err != nil {
+		// Edited Message is Not Archived
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+
+		return err
+	}
+
+	// Same Edit Might be Received More Than Once
+	msgEditedText := WhatsAppMessageText(msgContent)
+	if msgEditedText == msgText {
+		return nil
+	}
+
+	// Caption Edit Only Carry The New Caption
+	// So Only Text Message Raw Proto is Replaced
+	msgEditedRaw := msgRaw
+	if msgType == "text" {
+		msgBytes, err := proto.Marshal(msgContent)
+		if err != nil {
+			return err
+		}
+
+		msgEditedRaw = base64.StdEncoding.EncodeToString(msgBytes)
+	}
+
+	// Keep Previous Content as Edit History
+	// Then Replace Archived Content with Edited Content
+	tx, err := WhatsAppDatastoreDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`INSERT INTO whatsapp_message_edits (jid, id, text, raw, replaced_at) VALUES ($1, $2, $3, $4, $5)`,
+		jid, id, msgText, msgRaw, editedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`UPDATE whatsapp_messages SET text=$1, raw=$2 WHERE jid=$3 AND id=$4`,
+		msgEditedText, msgEditedRaw, jid, id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func WhatsAppMessageGetEdits(jid string, id string) ([]MessageEdit, error) {
+	rows, err := WhatsAppDatastoreDB.Query(`SELECT text, replaced_at FROM whatsapp_message_edits
+		WHERE jid=$1 AND id=$2 ORDER BY replaced_at ASC`, jid, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var edits []MessageEdit
+	for rows.Next() {
+		var edit MessageEdit
+
+		err = rows.Scan(&edit.Text, &edit.ReplacedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		edits = append(edits, edit)
+	}
+
+	return edits, rows.Err()
+}
+
+func WhatsAppMessageGet(jid string, id string) (ArchivedMessage, *waproto.Message, error) {
+	var message ArchivedMessage
+	var msgMedia EventMessageMedia
+	var msgMediaLength int64
+	var msgRaw string
+
+	// Get Archived Message from Datastore
+	row := WhatsAppDatastoreDB.QueryRow(`SELECT id, chat_jid, sender_jid, direction, type, text, media_mimetype, media_filename, media_filelength, raw, timestamp
+		FROM whatsapp_messages WHERE jid=$1 AND id=$2`, jid, id)
+
+	err := row.Scan(&message.ID, &message.ChatJID, &message.SenderJID, &message.Direction, &message.Type, &message.Text,
+		&msgMedia.MimeType, &msgMedia.FileName, &msgMediaLength, &msgRaw, &message.Timestamp)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return message, nil, ErrMessageNotFound
+		}
+
+		return message, nil, err
+	}
+
+	if len(msgMedia.MimeType) > 0 {
+		msgMedia.FileLength = uint64(msgMediaLength)
+		message.Media = &msgMedia
+	}
+
+	// Decode Raw Message Proto
+	msgContent := &waproto.Message{}
+
+	msgBytes, err := base64.StdEncoding.DecodeString(msgRaw)
+	if err == nil {
+		err = proto.Unmarshal(msgBytes, msgContent)
+	}
+	if err != nil {
+		return message, nil, err
+	}
+
+	return message, msgContent, nil
+}
+
+func WhatsAppMessageSender(jid string, rjid types.JID, id string, sender string) (types.JID, error) {
+	// Explicit Sender Takes Precedence
+	if len(sender) > 0 {
+		return WhatsAppComposeJID(sender), nil
+	}
+
+	// Look Up Message Sender from Message Archive
+	archived, _, err := WhatsAppMessageGet(jid, id)
+	if err == nil && len(archived.SenderJID) > 0 {
+		senderJID, err := types.ParseJID(archived.SenderJID)
+		if err == nil {
+			return senderJID, nil
+		}
+	}
+
+	// Message Sender in Personal Chat is The Remote Party
+	if rjid.Server != types.GroupServer {
+		return rjid, nil
+	}
+
+	return types.EmptyJID, errors.New("WhatsApp Message Sender is Not Found, Please Provide Message Sender")
+}
+
+func WhatsAppMessageList(jid string, filter MessageFilter) (MessagePage, error) {
+	var page MessagePage
+
+	// Make Sure Limit is in Allowed Range
+	if filter.Limit <= 0 {
+		filter.Limit = whatsAppMessageLimitDefault
+	} else if filter.Limit > whatsAppMessageLimitMax {
+		filter.Limit = whatsAppMessageLimitMax
+	}
+
+	// Compose Query Conditions Based on Filter
+	conditions := []string{"jid=$1"}
+	args := []interface{}{jid}
+
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, strings.ReplaceAll(condition, "?", "$"+strconv.Itoa(len(args))))
+	}
+
+	if len(filter.ChatJID) > 0 {
+		addCondition("chat_jid=?", filter.ChatJID)
+	}
+
+	if len(filter.Type) > 0 {
+		addCondition("type=?", filter.Type)
+	}
+
+	if filter.Since > 0 {
+		addCondition("timestamp>=?", filter.Since)
+	}
+
+	if filter.Until > 0 {
+		addCondition("timestamp<=?", filter.Until)
+	}
+
+	// Continue From The Last Message of Previous Page
+	if len(filter.Cursor) > 0 {
+		cursorTimestamp, cursorID, err := WhatsAppMessageDecodeCursor(filter.Cursor)
+		if err != nil {
+			return page, err
+		}
+
+		args = append(args, cursorTimestamp, cursorID)
+		conditions = append(conditions, "(timestamp<$"+strconv.Itoa(len(args)-1)+
+			" OR (timestamp=$"+strconv.Itoa(len(args)-1)+" AND id<$"+strconv.Itoa(len(args))+"))")
+	}
+
+	// Fetch One More Row to Know if Next Page is Exist
+	args = append(args, filter.Limit+1)
+
+	rows, err := WhatsAppDatastoreDB.Query(`SELECT id, chat_jid, sender_jid, direction, type, text, media_mimetype, media_filename, media_filelength, timestamp
+		FROM whatsapp_messages WHERE `+strings.Join(conditions, " AND ")+`
+		ORDER BY timestamp DESC, id DESC LIMIT $`+strconv.Itoa(len(args)), args...)
+	if err != nil {
+		return page, err
+	}
+	defer rows.Close()
+
+	page.Messages = []ArchivedMessage{}
+
+	for rows.Next() {
+		var message ArchivedMessage
+		var msgMedia EventMessageMedia
+		var msgMediaLength int64
+
+		err = rows.Scan(&message.ID, &message.ChatJID, &message.SenderJID, &message.Direction, &message.Type, &message.Text,
+			&msgMedia.MimeType, &msgMedia.FileName, &msgMediaLength, &message.Timestamp)
+		if err != nil {
+			return page, err
+		}
+
+		if len(msgMedia.MimeType) > 0 {
+			msgMedia.FileLength = uint64(msgMediaLength)
+			message.Media = &msgMedia
+		}
+
+		page.Messages = append(page.Messages, message)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return page, err
+	}
+
+	// Set Next Page Cursor if There are More Messages
+	if len(page.Messages) > filter.Limit {
+		page.Messages = page.Messages[:filter.Limit]
+
+		lastMessage := page.Messages[filter.Limit-1]
+		page.NextCursor = WhatsAppMessageEncodeCursor(lastMessage.Timestamp, lastMessage.ID)
+	}
+
+	return page, nil
+}
+
+func WhatsAppMessageEncodeCursor(timestamp int64, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(timestamp, 10) + ":" + id))
+}
+
+func WhatsAppMessageDecodeCursor(cursor string) (int64, string, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, "", errors.New("WhatsApp Message Cursor is Not Valid")
+	}
+
+	buffers := strings.SplitN(string(decoded), ":", 2)
+	if len(buffers) != 2 {
+		return 0, "", errors.New("WhatsApp Message Cursor is Not Valid")
+	}
+
+	timestamp, err := strconv.ParseInt(buffers[0], 10, 64)
+	if err != nil {
+		return 0, "", errors.New("WhatsApp Message Cursor is Not Valid")
+	}
+
+	return timestamp, buffers[1], nil
+}
@@ -0,0 +1,65 @@
+package whatsapp
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestWhatsAppMessageCursor(t *testing.T) {
+	tests := []struct {
+		name      string
+		timestamp int64
+		id        string
+	}{
+		{name: "Regular", timestamp: 1700000000, id: "3EB0C431C26A1916E07D"},
+		{name: "Zero Timestamp", timestamp: 0, id: "ABC"},
+		{name: "Negative Timestamp", timestamp: -1, id: "ABC"},
+		{name: "Maximum Timestamp", timestamp: 1<<63 - 1, id: "ABC"},
+		{name: "ID With Separator", timestamp: 1700000000, id: "ABC:DEF:GHI"},
+		{name: "Empty ID", timestamp: 1700000000, id: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timestamp, id, err := WhatsAppMessageDecodeCursor(WhatsAppMessageEncodeCursor(tt.timestamp, tt.id))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if timestamp != tt.timestamp || id != tt.id {
+				t.Fatalf("got (%d, %q), want (%d, %q)", timestamp, id, tt.timestamp, tt.id)
+			}
+		})
+	}
+}
+
+func TestWhatsAppMessageDecodeCursorMalformed(t *testing.T) {
+	encode := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "Empty", cursor: ""},
+		{name: "Not Base64", cursor: "!!!"},
+		{name: "Padded Base64", cursor: base64.URLEncoding.EncodeToString([]byte("1:AB"))},
+		{name: "Standard Base64", cursor: base64.RawStdEncoding.EncodeToString([]byte("1:\xfb\xff"))},
+		{name: "Truncated Base64", cursor: encode("1700000000:ABC")[:1]},
+		{name: "Missing Separator", cursor: encode("1700000000")},
+		{name: "Empty Timestamp", cursor: encode(":ABC")},
+		{name: "Text Timestamp", cursor: encode("abc:ABC")},
+		{name: "Float Timestamp", cursor: encode("1.5:ABC")},
+		{name: "Oversized Timestamp", cursor: encode("99999999999999999999:ABC")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := WhatsAppMessageDecodeCursor(tt.cursor)
+			if err == nil {
+				t.Fatalf("expected error for cursor %q", tt.cursor)
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package whatsapp
+
+import (
+	"errors"
+	"regexp"
+
+	waproto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+type SendOptions struct {
+	QuotedID     string
+	QuotedSender string
+	QuotedBody   string
+	Mentions     []string
+
+	isResolved    bool
+	quotedSender  types.JID
+	quotedMessage *waproto.Message
+	mentionedJIDs []string
+}
+
+var whatsAppMentionRegex = regexp.MustCompile(`@\+?(\d{5,16})`)
+
+func WhatsAppResolveSendOptions(jid string, rjid types.JID, text string, msgOptions SendOptions) (SendOptions, error) {
+	// Look Up Quoted Message if Sent as Reply
+	if len(msgOptions.QuotedID) > 0 {
+		var quotedSender types.JID
+		var quotedMessage *waproto.Message
+
+		// Look Up Quoted Message from Message Archive
+		archived, archivedContent, err := WhatsAppMessageGet(jid, msgOptions.QuotedID)
+		if err == nil {
+			quotedSender, _ = types.ParseJID(archived.SenderJID)
+			quotedMessage = proto.Clone(archivedContent).(*waproto.Message)
+
+			// Quoted Message Should Not Carry Its Own Reply Context
+			quotedContext := WhatsAppMessageContextInfo(quotedMessage)
+			if quotedContext != nil {
+				proto.Reset(quotedContext)
+			}
+		}
+
+		// Explicit Quoted Sender and Body Take Precedence
+		if len(msgOptions.QuotedSender) > 0 {
+			quotedSender = WhatsAppComposeJID(msgOptions.QuotedSender)
+		}
+
+		if len(msgOptions.QuotedBody) > 0 {
+			quotedMessage = &waproto.Message{
+				Conversation: proto.String(msgOptions.QuotedBody),
+			}
+		}
+
+		// Quoted Sender in Personal Chat is The Remote Party
+		if quotedSender.IsEmpty() && rjid.Server != types.GroupServer {
+			quotedSender = rjid
+		}
+
+		if quotedSender.IsEmpty() || quotedMessage == nil {
+			return msgOptions, errors.New("WhatsApp Quoted Message is Not Found, Please Provide Quoted Sender and Body")
+		}
+
+		msgOptions.quotedSender = quotedSender
+		msgOptions.quotedMessage = quotedMessage
+	}
+
+	// Compose Mentioned JIDs from Explicit List and Text Tokens
+	mentionedJIDs, err := WhatsAppComposeMentions(jid, rjid, text, msgOptions.Mentions)
+	if err != nil {
+		return msgOptions, err
+	}
+
+	msgOptions.mentionedJIDs = mentionedJIDs
+	msgOptions.isResolved = true
+
+	return msgOptions, nil
+}
+
+func WhatsAppApplySendOptions(jid string, rjid types.JID, msgContent *waproto.Message, msgOptions SendOptions) error {
+	var err error
+
+	// Resolve Send Options from Message Content
+	// When Caller Has Not Resolved Them Before Composing Message
+	if !msgOptions.isResolved {
+		msgOptions, err = WhatsAppResolveSendOptions(jid, rjid, WhatsAppMessageText(msgContent), msgOptions)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Compose Quoted Message Context if Sent as Reply
+	if msgOptions.quotedMessage != nil {
+		msgContext := WhatsAppMessageEnsureContextInfo(msgContent)
+		if msgContext == nil {
+			return errors.New("WhatsApp Message Type Cannot be Sent as Reply")
+		}
+
+		msgContext.StanzaID = proto.String(msgOptions.QuotedID)
+		msgContext.Participant = proto.String(msgOptions.quotedSender.ToNonAD().String())
+		msgContext.QuotedMessage = msgOptions.quotedMessage
+	}
+
+	if len(msgOptions.mentionedJIDs) > 0 {
+		msgContext := WhatsAppMessageEnsureContextInfo(msgContent)
+		if msgContext == nil {
+			return errors.New("WhatsApp Message Type Cannot Contain Mentions")
+		}
+
+		msgContext.MentionedJID = msgOptions.mentionedJIDs
+	}
+
+	return nil
+}
+
+func WhatsAppComposeMentions(jid string, rjid types.JID, text string, mentions []string) ([]string, error) {
+	var detected []string
+
+	// Detect "@628123..." Tokens in Text
+	// Only in Group Chat Since Personal Chat Has No Participant to Validate
+	// And Number in Text Would be Mentioned Without Sender Intention
+	if rjid.Server == types.GroupServer {
+		for _, match := range whatsAppMentionRegex.FindAllStringSubmatch(text, -1) {
+			detected = append(detected, match[1])
+		}
+	}
+
+	if len(mentions) == 0 && len(detected) == 0 {
+		return nil, nil
+	}
+
+	// Get Group Participants to Validate Mentions
+	var participants map[string]types.JID
+	var isParticipantHidden bool
+
+	if rjid.Server == types.GroupServer {
+		client := WhatsAppClient.Get(jid)
+		if client == nil {
+			return nil, errors.New("WhatsApp Client is not Valid")
+		}
+
+		groupInfo, err := client.GetGroupInfo(rjid)
+		if err != nil {
+			return nil, err
+		}
+
+		// Participant in LID Addressed Group Can be Identified by LID
+		// So Match Mention Against Both Phone Number and LID
+		participants = make(map[string]types.JID)
+		for _, participant := range groupInfo.Participants {
+			participants[participant.JID.User] = participant.JID.ToNonAD()
+
+			if !participant.LID.IsEmpty() {
+				participants[participant.LID.User] = participant.LID.ToNonAD()
+			}
+
+			// Phone Number of Participant Only Known by LID is Not Available
+			if participant.JID.Server == types.HiddenUserServer {
+				isParticipantHidden = true
+			}
+		}
+	}
+
+	var mentionedJIDs []string
+	isMentioned := make(map[string]bool)
+
+	addMention := func(id string, isExplicit bool) error {
+		mentionJID := WhatsAppComposeJID(id)
+
+		participantJID, isParticipant := participants[mentionJID.User]
+		if isParticipant {
+			// Mention Participant Using The Same Address Used by Group
+			mentionJID = participantJID
+		} else if participants != nil {
+			// Explicit Mention Must be Group Participant
+			// While Detected Token Might Just be a Number in Text
+			// Explicit Mention Cannot be Validated When Some Participant Phone Number is Hidden
+			if !isExplicit {
+				return nil
+			}
+
+			if !isParticipantHidden {
+				return errors.New("WhatsApp Mention " + mentionJID.User + " is Not Group Participant")
+			}
+		}
+
+		if !isMentioned[mentionJID.User] {
+			isMentioned[mentionJID.User] = true
+			mentionedJIDs = append(mentionedJIDs, mentionJID.String())
+		}
+
+		return nil
+	}
+
+	for _, mention := range mentions {
+		err := addMention(mention, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, mention := range detected {
+		_ = addMention(mention, false)
+	}
+
+	return mentionedJIDs, nil
+}
+
+func WhatsAppMessageEnsureContextInfo(msg *waproto.Message) *waproto.ContextInfo {
+	// Plain Conversation Cannot Carry Context Information
+	// So Convert it to Extended Text Message
+	if msg.Conversation != nil {
+		msg.ExtendedTextMessage = &waproto.ExtendedTextMessage{
+			Text: msg.Conversation,
+		}
+		msg.Conversation = nil
+	}
+
+	msg = WhatsAppMessageUnwrap(msg)
+
+	switch {
+	case msg.GetExtendedTextMessage() != nil:
+		if msg.ExtendedTextMessage.ContextInfo == nil {
+			msg.ExtendedTextMessage.ContextInfo = &waproto.ContextInfo{}
+		}
+		return msg.ExtendedTextMessage.ContextInfo
+
+	case msg.GetImageMessage() != nil:
+		if msg.ImageMessage.ContextInfo == nil {
+			msg.ImageMessage.ContextInfo = &waproto.ContextInfo{}
+		}
+		return msg.ImageMessage.ContextInfo
+
+	case msg.GetVideoMessage() != nil:
+		if msg.VideoMessage.ContextInfo == nil {
+			msg.VideoMessage.ContextInfo = &waproto.ContextInfo{}
+		}
+		return msg.VideoMessage.ContextInfo
+
+	case msg.GetAudioMessage() != nil:
+		if msg.AudioMessage.ContextInfo == nil {
+			msg.AudioMessage.ContextInfo = &waproto.ContextInfo{}
+		}
+		return msg.AudioMessage.ContextInfo
+
+	case msg.GetDocumentMessage() != nil:
+		if msg.DocumentMessage.ContextInfo == nil {
+			msg.DocumentMessage.ContextInfo = &waproto.ContextInfo{}
+		}
+		return msg.DocumentMessage.ContextInfo
+
+	case msg.GetStickerMessage() != nil:
+		if msg.StickerMessage.ContextInfo == nil {
+			msg.StickerMessage.ContextInfo = &waproto.ContextInfo{}
+		}
+		return msg.StickerMessage.ContextInfo
+
+	case msg.GetLocationMessage() != nil:
+		if msg.LocationMessage.ContextInfo == nil {
+			msg.LocationMessage.ContextInfo = &waproto.ContextInfo{}
+		}
+		return msg.LocationMessage.ContextInfo
+
+	case msg.GetLiveLocationMessage() != nil:
+		if msg.LiveLocationMessage.ContextInfo == nil {
+			msg.LiveLocationMessage.ContextInfo = &waproto.ContextInfo{}
+		}
+		return msg.LiveLocationMessage.ContextInfo
+
+	case msg.GetContactMessage() != nil:
+		if msg.ContactMessage.ContextInfo == nil {
+			msg.ContactMessage.ContextInfo = &waproto.ContextInfo{}
+		}
+		return msg.ContactMessage.ContextInfo
+
+	case msg.GetPollCreationMessage() != nil:
+		if msg.PollCreationMessage.ContextInfo == nil {
+			msg.PollCreationMessage.ContextInfo = &waproto.ContextInfo{}
+		}
+		return msg.PollCreationMessage.ContextInfo
+
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,362 @@
+package whatsapp
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dimaskiddo/go-whatsapp-multidevice-rest/pkg/env"
+	"github.com/dimaskiddo/go-whatsapp-multidevice-rest/pkg/log"
+)
+
+type WebhookDelivery struct {
+	ID            string          `json:"id"`
+	Event         string          `json:"event"`
+	Payload       json.RawMessage `json:"payload"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt int64           `json:"next_attempt_at"`
+	LastError     string          `json:"last_error"`
+	CreatedAt     int64           `json:"created_at"`
+	UpdatedAt     int64           `json:"updated_at"`
+}
+
+const (
+	whatsAppOutboxStatusPending    = "pending"
+	whatsAppOutboxStatusProcessing = "processing"
+	whatsAppOutboxStatusDead       = "dead"
+)
+
+const whatsAppOutboxBatchSize = 100
+const whatsAppOutboxQueueSize = 1024
+
+var WhatsAppWebhookMaxAttempts int
+var WhatsAppWebhookRetryInterval int
+var WhatsAppWebhookConcurrency int
+
+var whatsAppOutboxMutex sync.Mutex
+var whatsAppOutboxQueue = make(chan EventPayload, whatsAppOutboxQueueSize)
+
+func init() {
+	var err error
+
+	WhatsAppWebhookMaxAttempts, err = env.GetEnvInt("WHATSAPP_WEBHOOK_MAX_ATTEMPTS")
+	if err != nil || WhatsAppWebhookMaxAttempts <= 0 {
+		WhatsAppWebhookMaxAttempts = 10
+	}
+
+	WhatsAppWebhookRetryInterval, err = env.GetEnvInt("WHATSAPP_WEBHOOK_RETRY_INTERVAL_SECONDS")
+	if err != nil || WhatsAppWebhookRetryInterval <= 0 {
+		WhatsAppWebhookRetryInterval = 5
+	}
+
+	WhatsAppWebhookConcurrency, err = env.GetEnvInt("WHATSAPP_WEBHOOK_CONCURRENCY")
+	if err != nil || WhatsAppWebhookConcurrency <= 0 {
+		WhatsAppWebhookConcurrency = 8
+	}
+
+	// Start Outbox Writer
+	go whatsAppWebhookOutboxWriter()
+}
+
+func WhatsAppWebhookEnqueue(payload EventPayload) error {
+	// Skip Queueing When Webhook URL is Not Configured
+	// Or Event Type is Not Subscribed
+	webhook, isConfigured := whatsAppWebhookResolveCached(payload.JID)
+	if !isConfigured || !WhatsAppWebhookIsSubscribed(webhook, payload.Event) {
+		return nil
+	}
+
+	// Hand Over Event Payload to Outbox Writer
+	// So Event Handler is Not Blocked by Datastore
+	// Save Directly When Writer is Falling Behind So Event is Not Lost
+	select {
+	case whatsAppOutboxQueue <- payload:
+		return nil
+	default:
+		return whatsAppWebhookOutboxSave(payload)
+	}
+}
+
+func whatsAppWebhookOutboxWriter() {
+	for payload := range whatsAppOutboxQueue {
+		err := whatsAppWebhookOutboxSave(payload)
+		if err != nil {
+			// Mask JID for Logging Information
+			maskJID := payload.JID[0:len(payload.JID)-4] + "xxxx"
+
+			log.Print(nil).Error("Failed to Queue Webhook Event '" + payload.Event + "' for " + maskJID + ", " + err.Error())
+			continue
+		}
+
+		// Try to Deliver Immediately in Background
+		// When All Queued Events are Saved
+		if len(whatsAppOutboxQueue) == 0 {
+			go WhatsAppWebhookOutboxProcess()
+		}
+	}
+}
+
+func whatsAppWebhookOutboxSave(payload EventPayload) error {
+	// Encode Event Payload to JSON
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	id, err := WhatsAppDatastoreGenerateID()
+	if err != nil {
+		return err
+	}
+
+	// Save Event Payload to Outbox
+	now := time.Now().Unix()
+
+	_, err = WhatsAppDatastoreDB.Exec(`INSERT INTO whatsapp_webhook_outbox
+		(id, jid, event, payload, status, attempts, next_attempt_at, last_error, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, 0, $6, '', $6, $6)`,
+		id, payload.JID, payload.Event, string(body), whatsAppOutboxStatusPending, now)
+
+	return err
+}
+
+type whatsAppOutboxItem struct {
+	ID       string
+	JID      string
+	Payload  string
+	Attempts int
+}
+
+func WhatsAppWebhookOutboxProcess() {
+	// Only Allow Single Outbox Worker at a Time in This Process
+	// Remaining Deliveries Will be Picked by The Next Run
+	if !whatsAppOutboxMutex.TryLock() {
+		return
+	}
+	defer whatsAppOutboxMutex.Unlock()
+
+	items, err := whatsAppWebhookOutboxClaim()
+	if err != nil {
+		log.Print(nil).Error("Failed to Load Webhook Outbox, " + err.Error())
+		return
+	}
+
+	// Group Claimed Deliveries by JID
+	// So Deliveries for The Same Device Keep Their Order
+	var jids []string
+	itemsByJID := make(map[string][]whatsAppOutboxItem)
+
+	for _, item := range items {
+		if _, isExist := itemsByJID[item.JID]; !isExist {
+			jids = append(jids, item.JID)
+		}
+
+		itemsByJID[item.JID] = append(itemsByJID[item.JID], item)
+	}
+
+	// Deliver Each JID Concurrently with Bounded Workers
+	// So Slow Receiver Does Not Block Other Devices
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, WhatsAppWebhookConcurrency)
+
+	for _, jid := range jids {
+		wg.Add(1)
+		workers <- struct{}{}
+
+		go func(jidItems []whatsAppOutboxItem) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+
+			for _, item := range jidItems {
+				whatsAppWebhookOutboxDeliver(item)
+			}
+		}(itemsByJID[jid])
+	}
+
+	wg.Wait()
+}
+
+func whatsAppWebhookOutboxClaim() ([]whatsAppOutboxItem, error) {
+	now := time.Now().Unix()
+
+	// Claimed Deliveries are Leased Using Next Attempt Time
+	// Lease Covers Worst Case Where All Claimed Deliveries Belong to Single JID
+	// And Expired Lease Can be Claimed Again When Worker is Gone
+	lockedUntil := now + int64(WhatsAppWebhookTimeout*whatsAppOutboxBatchSize)
+
+	// Skip Rows Being Claimed by Other Replicas on PostgreSQL
+	// SQLite Serialize Writers So it Does Not Need Row Locking
+	selectLock := ""
+	if WhatsAppDatastoreType == "postgres" {
+		selectLock = " FOR UPDATE SKIP LOCKED"
+	}
+
+	// Claim Due Deliveries Atomically So Every Delivery is Only Picked by One Worker
+	// Status is Checked Again on The Updated Row in Case Another Worker Claimed it First
+	rows, err := WhatsAppDatastoreDB.Query(`UPDATE whatsapp_webhook_outbox SET status=$1, next_attempt_at=$2, updated_at=$3
+		WHERE status IN ($4, $1) AND next_attempt_at<=$3 AND id IN (
+			SELECT id FROM whatsapp_webhook_outbox WHERE status IN ($4, $1) AND next_attempt_at<=$3
+			ORDER BY created_at LIMIT `+strconv.Itoa(whatsAppOutboxBatchSize)+selectLock+`
+		) RETURNING id, jid, payload, attempts`,
+		whatsAppOutboxStatusProcessing, lockedUntil, now, whatsAppOutboxStatusPending)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []whatsAppOutboxItem
+	for rows.Next() {
+		var item whatsAppOutboxItem
+
+		err = rows.Scan(&item.ID, &item.JID, &item.Payload, &item.Attempts)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func whatsAppWebhookOutboxDeliver(item whatsAppOutboxItem) {
+	// Deliver to Current Webhook Configuration
+	// Drop The Delivery if Webhook is No Longer Configured
+	webhook, isConfigured := WhatsAppWebhookResolve(item.JID)
+	if !isConfigured {
+		_, _ = WhatsAppDatastoreDB.Exec(`DELETE FROM whatsapp_webhook_outbox WHERE id=$1`, item.ID)
+		return
+	}
+
+	err := WhatsAppWebhookDeliver(webhook, []byte(item.Payload))
+	if err == nil {
+		_, _ = WhatsAppDatastoreDB.Exec(`DELETE FROM whatsapp_webhook_outbox WHERE id=$1`, item.ID)
+		return
+	}
+
+	// Move to Dead-Letter When Maximum Attempts Reached
+	// Otherwise Release The Claim and Schedule Next Attempt with Exponential Backoff
+	now := time.Now().Unix()
+	item.Attempts++
+
+	status := whatsAppOutboxStatusPending
+	if item.Attempts >= WhatsAppWebhookMaxAttempts {
+		status = whatsAppOutboxStatusDead
+
+		// Mask JID for Logging Information
+		maskJID := item.JID[0:len(item.JID)-4] + "xxxx"
+
+		log.Print(nil).Error("Webhook Delivery " + item.ID + " for " + maskJID + " Moved to Dead-Letter, " + err.Error())
+	}
+
+	_, _ = WhatsAppDatastoreDB.Exec(`UPDATE whatsapp_webhook_outbox
+		SET status=$1, attempts=$2, next_attempt_at=$3, last_error=$4, updated_at=$5 WHERE id=$6`,
+		status, item.Attempts, now+WhatsAppWebhookBackoff(item.Attempts), err.Error(), now, item.ID)
+}
+
+func WhatsAppWebhookBackoff(attempts int) int64 {
+	// Double Retry Interval on Every Attempt
+	// And Cap it to One Hour
+	backoff := int64(WhatsAppWebhookRetryInterval)
+	for i := 1; i < attempts && backoff < 3600; i++ {
+		backoff *= 2
+	}
+
+	if backoff > 3600 {
+		backoff = 3600
+	}
+
+	return backoff
+}
+
+func WhatsAppWebhookDeadList(jid string) ([]WebhookDelivery, error) {
+	// Get Dead-Lettered Deliveries from Outbox
+	rows, err := WhatsAppDatastoreDB.Query(`SELECT id, event, payload, attempts, next_attempt_at, last_error, created_at, updated_at
+		FROM whatsapp_webhook_outbox WHERE jid=$1 AND status=$2 ORDER BY created_at`,
+		jid, whatsAppOutboxStatusDead)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []WebhookDelivery{}
+	for rows.Next() {
+		var delivery WebhookDelivery
+		var deliveryPayload string
+
+		err = rows.Scan(&delivery.ID, &delivery.Event, &deliveryPayload, &delivery.Attempts,
+			&delivery.NextAttemptAt, &delivery.LastError, &delivery.CreatedAt, &delivery.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		delivery.Payload = json.RawMessage(deliveryPayload)
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func WhatsAppWebhookDeadReplay(jid string, id string) (int64, error) {
+	now := time.Now().Unix()
+
+	// Reset Dead-Lettered Deliveries Back to Pending
+	// For Spesific Delivery ID or All Deliveries When ID is Empty
+	query := `UPDATE whatsapp_webhook_outbox SET status=$1, attempts=0, next_attempt_at=$2, updated_at=$2 WHERE jid=$3 AND status=$4`
+	args := []interface{}{whatsAppOutboxStatusPending, now, jid, whatsAppOutboxStatusDead}
+
+	if len(id) > 0 {
+		query += ` AND id=$5`
+		args = append(args, id)
+	}
+
+	res, err := WhatsAppDatastoreDB.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(id) > 0 && count == 0 {
+		return 0, errors.New("WhatsApp Webhook Dead-Letter Delivery is Not Found")
+	}
+
+	// Try to Deliver Replayed Deliveries in Background
+	go WhatsAppWebhookOutboxProcess()
+
+	return count, nil
+}
+
+func WhatsAppWebhookDeadPurge(jid string, id string) (int64, error) {
+	// Delete Dead-Lettered Deliveries from Outbox
+	// For Spesific Delivery ID or All Deliveries When ID is Empty
+	query := `DELETE FROM whatsapp_webhook_outbox WHERE jid=$1 AND status=$2`
+	args := []interface{}{jid, whatsAppOutboxStatusDead}
+
+	if len(id) > 0 {
+		query += ` AND id=$3`
+		args = append(args, id)
+	}
+
+	res, err := WhatsAppDatastoreDB.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(id) > 0 && count == 0 {
+		return 0, errors.New("WhatsApp Webhook Dead-Letter Delivery is Not Found")
+	}
+
+	return count, nil
+}
@@ -0,0 +1,192 @@
+package whatsapp
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"go.mau.fi/whatsmeow"
+	waproto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"github.com/dimaskiddo/go-whatsapp-multidevice-rest/pkg/log"
+)
+
+type PollResult struct {
+	ID              string             `json:"id"`
+	ChatJID         string             `json:"chat_jid"`
+	Question        string             `json:"question"`
+	SelectableCount int                `json:"selectable_count"`
+	Options         []PollOptionResult `json:"options"`
+	TotalVoters     int                `json:"total_voters"`
+}
+
+type PollOptionResult struct {
+	Name   string   `json:"name"`
+	Votes  int      `json:"votes"`
+	Voters []string `json:"voters"`
+}
+
+var ErrMessageNotPoll = errors.New("WhatsApp Message is Not a Poll")
+
+const (
+	whatsAppPollOptionMin = 2
+	whatsAppPollOptionMax = 12
+)
+
+func WhatsAppPollValidate(question string, options []string, selectableCount int) error {
+	if len(question) == 0 {
+		return errors.New("WhatsApp Poll Question is Empty")
+	}
+
+	if len(options) < whatsAppPollOptionMin || len(options) > whatsAppPollOptionMax {
+		return errors.New("WhatsApp Poll Options Should be Between 2 and 12 Options")
+	}
+
+	// Votes Only Carry Option Hash
+	// So Options Must be Unique to be Distinguishable
+	isOption := make(map[string]bool)
+	for _, option := range options {
+		if len(option) == 0 {
+			return errors.New("WhatsApp Poll Option is Empty")
+		}
+
+		if isOption[option] {
+			return errors.New("WhatsApp Poll Option " + option + " is Duplicated")
+		}
+
+		isOption[option] = true
+	}
+
+	if selectableCount < 0 || selectableCount > len(options) {
+		return errors.New("WhatsApp Poll Selectable Count Should be Between 0 and Number of Options")
+	}
+
+	return nil
+}
+
+func WhatsAppPollTrack(jid string, evt *events.Message) {
+	pollUpdate := evt.Message.GetPollUpdateMessage()
+	if pollUpdate == nil {
+		return
+	}
+
+	// Mask JID for Logging Information
+	maskJID := jid[0:len(jid)-4] + "xxxx"
+
+	client := WhatsAppClient.Get(jid)
+	if client == nil {
+		return
+	}
+
+	// Decrypt Poll Vote Using Poll Message Secret
+	pollVote, err := client.DecryptPollVote(evt)
+	if err != nil {
+		log.Print(nil).Error("Failed to Decrypt Poll Vote for " + maskJID + ", " + err.Error())
+		return
+	}
+
+	var selectedHashes []string
+	for _, selectedOption := range pollVote.GetSelectedOptions() {
+		selectedHashes = append(selectedHashes, hex.EncodeToString(selectedOption))
+	}
+
+	votedAt := pollUpdate.GetSenderTimestampMS() / 1000
+	if votedAt == 0 {
+		votedAt = evt.Info.Timestamp.Unix()
+	}
+
+	// Only Keep The Latest Vote of Each Voter
+	// Empty Selected Options Means Vote is Retracted
+	_, err = WhatsAppDatastoreDB.Exec(`INSERT INTO whatsapp_poll_votes (jid, poll_id, voter_jid, options, voted_at)
+		VALUES ($1, $2, $3, $4, $5) ON CONFLICT (jid, poll_id, voter_jid)
+		DO UPDATE SET options=excluded.options, voted_at=excluded.voted_at WHERE whatsapp_poll_votes.voted_at<=excluded.voted_at`,
+		jid, pollUpdate.GetPollCreationMessageKey().GetID(), evt.Info.Sender.ToNonAD().String(), strings.Join(selectedHashes, ","), votedAt)
+	if err != nil {
+		log.Print(nil).Error("Failed to Record Poll Vote for " + maskJID + ", " + err.Error())
+	}
+}
+
+func WhatsAppPollGet(jid string, id string) (PollResult, error) {
+	// Get Poll Question and Options from Message Archive
+	archived, archivedContent, err := WhatsAppMessageGet(jid, id)
+	if err != nil {
+		return PollResult{}, err
+	}
+
+	pollCreation := WhatsAppMessagePollCreation(archivedContent)
+	if pollCreation == nil {
+		return PollResult{}, ErrMessageNotPoll
+	}
+
+	pollResult := PollResult{
+		ID:              archived.ID,
+		ChatJID:         archived.ChatJID,
+		Question:        pollCreation.GetName(),
+		SelectableCount: int(pollCreation.GetSelectableOptionsCount()),
+		Options:         []PollOptionResult{},
+	}
+
+	// Map Option Hash to Option Result
+	var optionNames []string
+	for _, option := range pollCreation.GetOptions() {
+		optionNames = append(optionNames, option.GetOptionName())
+	}
+
+	optionIndexes := make(map[string]int)
+	for i, optionHash := range whatsmeow.HashPollOptions(optionNames) {
+		optionIndexes[hex.EncodeToString(optionHash)] = i
+		pollResult.Options = append(pollResult.Options, PollOptionResult{
+			Name:   optionNames[i],
+			Voters: []string{},
+		})
+	}
+
+	// Aggregate Recorded Votes
+	rows, err := WhatsAppDatastoreDB.Query(`SELECT voter_jid, options FROM whatsapp_poll_votes
+		WHERE jid=$1 AND poll_id=$2 ORDER BY voted_at ASC`, jid, id)
+	if err != nil {
+		return pollResult, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var voterJID, selectedHashes string
+
+		err = rows.Scan(&voterJID, &selectedHashes)
+		if err != nil {
+			return pollResult, err
+		}
+
+		if len(selectedHashes) == 0 {
+			continue
+		}
+
+		for _, selectedHash := range strings.Split(selectedHashes, ",") {
+			i, ok := optionIndexes[selectedHash]
+			if !ok {
+				continue
+			}
+
+			pollResult.Options[i].Votes++
+			pollResult.Options[i].Voters = append(pollResult.Options[i].Voters, voterJID)
+		}
+
+		pollResult.TotalVoters++
+	}
+
+	return pollResult, rows.Err()
+}
+
+func WhatsAppMessagePollCreation(msg *waproto.Message) *waproto.PollCreationMessage {
+	switch {
+	case msg.GetPollCreationMessage() != nil:
+		return msg.GetPollCreationMessage()
+	case msg.GetPollCreationMessageV2() != nil:
+		return msg.GetPollCreationMessageV2()
+	case msg.GetPollCreationMessageV3() != nil:
+		return msg.GetPollCreationMessageV3()
+	default:
+		return nil
+	}
+}
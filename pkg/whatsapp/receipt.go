@@ -0,0 +1,236 @@
+package whatsapp
+
+import (
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"github.com/dimaskiddo/go-whatsapp-multidevice-rest/pkg/log"
+)
+
+type MessageStatus struct {
+	ID          string                   `json:"id"`
+	ChatJID     string                   `json:"chat_jid"`
+	Status      string                   `json:"status"`
+	ServerAckAt int64                    `json:"server_ack_at,omitempty"`
+	Recipients  []MessageRecipientStatus `json:"recipients"`
+}
+
+type MessageRecipientStatus struct {
+	JID         string `json:"jid"`
+	Status      string `json:"status"`
+	DeliveredAt int64  `json:"delivered_at,omitempty"`
+	ReadAt      int64  `json:"read_at,omitempty"`
+	PlayedAt    int64  `json:"played_at,omitempty"`
+}
+
+type EventMessageStatus struct {
+	ID           string `json:"id"`
+	ChatJID      string `json:"chat_jid"`
+	RecipientJID string `json:"recipient_jid"`
+	Status       string `json:"status"`
+	Timestamp    int64  `json:"timestamp"`
+}
+
+const (
+	MessageStatusPending   = "pending"
+	MessageStatusServerAck = "server_ack"
+	MessageStatusDelivered = "delivered"
+	MessageStatusRead      = "read"
+	MessageStatusPlayed    = "played"
+)
+
+var whatsAppMessageStatusLevels = map[string]int{
+	MessageStatusPending:   0,
+	MessageStatusServerAck: 1,
+	MessageStatusDelivered: 2,
+	MessageStatusRead:      3,
+	MessageStatusPlayed:    4,
+}
+
+func WhatsAppReceiptStatus(receiptType types.ReceiptType) string {
+	switch receiptType {
+	case types.ReceiptTypeDelivered:
+		return MessageStatusDelivered
+	case types.ReceiptTypeRead:
+		return MessageStatusRead
+	case types.ReceiptTypePlayed:
+		return MessageStatusPlayed
+	default:
+		return ""
+	}
+}
+
+func WhatsAppReceiptRecord(jid string, id string, recipientJID string, status string, timestamp int64) error {
+	// Only Keep The First Time Each State is Reached
+	_, err := WhatsAppDatastoreDB.Exec(`INSERT INTO whatsapp_message_receipts (jid, id, recipient_jid, status, timestamp)
+		VALUES ($1, $2, $3, $4, $5) ON CONFLICT (jid, id, recipient_jid, status) DO NOTHING`,
+		jid, id, recipientJID, status, timestamp)
+
+	return err
+}
+
+func WhatsAppReceiptTrack(jid string, evt *events.Receipt) {
+	// Only Track Receipts Sent by Recipients of Our Messages
+	status := WhatsAppReceiptStatus(evt.Type)
+	if evt.IsFromMe || len(status) == 0 {
+		return
+	}
+
+	recipientJID := evt.Sender.ToNonAD().String()
+
+	for _, id := range evt.MessageIDs {
+		err := WhatsAppReceiptRecord(jid, id, recipientJID, status, evt.Timestamp.Unix())
+		if err != nil {
+			// Mask JID for Logging Information
+			maskJID := jid[0:len(jid)-4] + "xxxx"
+
+			log.Print(nil).Error("Failed to Record Message Receipt for " + maskJID + ", " + err.Error())
+			continue
+		}
+
+		// Publish Message Status Event
+		WhatsAppEventPublish(jid, "message.status", EventMessageStatus{
+			ID:           id,
+			ChatJID:      evt.Chat.String(),
+			RecipientJID: recipientJID,
+			Status:       status,
+			Timestamp:    evt.Timestamp.Unix(),
+		})
+	}
+}
+
+func WhatsAppMessageGetStatus(jid string, id string) (MessageStatus, error) {
+	// Make Sure Message is Exist in Archive
+	message, _, err := WhatsAppMessageGet(jid, id)
+	if err != nil {
+		return MessageStatus{}, err
+	}
+
+	msgStatus := MessageStatus{
+		ID:         message.ID,
+		ChatJID:    message.ChatJID,
+		Status:     MessageStatusPending,
+		Recipients: []MessageRecipientStatus{},
+	}
+
+	// Get Recorded Receipts from Datastore
+	rows, err := WhatsAppDatastoreDB.Query(`SELECT recipient_jid, status, timestamp FROM whatsapp_message_receipts
+		WHERE jid=$1 AND id=$2 ORDER BY timestamp ASC`, jid, id)
+	if err != nil {
+		return msgStatus, err
+	}
+	defer rows.Close()
+
+	recipients := make(map[string]*MessageRecipientStatus)
+	var recipientOrder []string
+
+	for rows.Next() {
+		var recipientJID, status string
+		var timestamp int64
+
+		err = rows.Scan(&recipientJID, &status, &timestamp)
+		if err != nil {
+			return msgStatus, err
+		}
+
+		if status == MessageStatusServerAck {
+			msgStatus.ServerAckAt = timestamp
+			continue
+		}
+
+		recipient, ok := recipients[recipientJID]
+		if !ok {
+			recipient = &MessageRecipientStatus{JID: recipientJID, Status: MessageStatusPending}
+			recipients[recipientJID] = recipient
+			recipientOrder = append(recipientOrder, recipientJID)
+		}
+
+		switch status {
+		case MessageStatusDelivered:
+			recipient.DeliveredAt = timestamp
+		case MessageStatusRead:
+			recipient.ReadAt = timestamp
+		case MessageStatusPlayed:
+			recipient.PlayedAt = timestamp
+		}
+
+		// Recipient Status is The Highest State Reached
+		if whatsAppMessageStatusLevels[status] > whatsAppMessageStatusLevels[recipient.Status] {
+			recipient.Status = status
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return msgStatus, err
+	}
+
+	if msgStatus.ServerAckAt > 0 {
+		msgStatus.Status = MessageStatusServerAck
+	}
+
+	// Group Members Without Any Receipt are Still Pending
+	// So Overall Status Does Not Only Reflect Members Who Replied
+	// Receipt Sender Can be Either Phone Number or LID of The Member
+	for _, participant := range whatsAppReceiptGroupParticipants(jid, message.ChatJID) {
+		participantJID := participant.JID.ToNonAD().String()
+
+		if _, ok := recipients[participantJID]; ok {
+			continue
+		}
+
+		if !participant.LID.IsEmpty() {
+			if _, ok := recipients[participant.LID.ToNonAD().String()]; ok {
+				continue
+			}
+		}
+
+		recipients[participantJID] = &MessageRecipientStatus{JID: participantJID, Status: MessageStatusPending}
+		recipientOrder = append(recipientOrder, participantJID)
+	}
+
+	// Overall Status is The Lowest State Reached by Every Recipient
+	// Like Blue Ticks Only Shown When All Recipients Read The Message
+	for i, recipientJID := range recipientOrder {
+		recipient := recipients[recipientJID]
+		msgStatus.Recipients = append(msgStatus.Recipients, *recipient)
+
+		if i == 0 || whatsAppMessageStatusLevels[recipient.Status] < whatsAppMessageStatusLevels[msgStatus.Status] {
+			msgStatus.Status = recipient.Status
+		}
+	}
+
+	return msgStatus, nil
+}
+
+func whatsAppReceiptGroupParticipants(jid string, chatJID string) []types.GroupParticipant {
+	var participants []types.GroupParticipant
+
+	remoteJID, err := types.ParseJID(chatJID)
+	if err != nil || remoteJID.Server != types.GroupServer {
+		return participants
+	}
+
+	client := WhatsAppClient.Get(jid)
+	if client == nil || WhatsAppIsClientOK(jid) != nil || client.Store.ID == nil {
+		return participants
+	}
+
+	// Status Falls Back to Recorded Receipts Only
+	// When Group Participants Cannot be Loaded
+	groupInfo, err := client.GetGroupInfo(remoteJID)
+	if err != nil {
+		return participants
+	}
+
+	for _, participant := range groupInfo.Participants {
+		// Skip Our Own Device
+		if participant.JID.User == client.Store.ID.User {
+			continue
+		}
+
+		participants = append(participants, participant)
+	}
+
+	return participants
+}
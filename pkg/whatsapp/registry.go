@@ -0,0 +1,154 @@
+package whatsapp
+
+import (
+	"sync"
+
+	"go.mau.fi/whatsmeow"
+)
+
+type ClientState string
+
+const (
+	ClientStateInitializing ClientState = "initializing"
+	ClientStatePairing      ClientState = "pairing"
+	ClientStateConnected    ClientState = "connected"
+	ClientStateDisconnected ClientState = "disconnected"
+	ClientStateLoggedOut    ClientState = "logged_out"
+)
+
+type ClientRegistry struct {
+	mutex   sync.RWMutex
+	clients map[string]*whatsmeow.Client
+	states  map[string]ClientState
+	logins  map[string]*clientLogin
+}
+
+type clientLogin struct {
+	mutex sync.Mutex
+	refs  int
+}
+
+func NewClientRegistry() *ClientRegistry {
+	return &ClientRegistry{
+		clients: make(map[string]*whatsmeow.Client),
+		states:  make(map[string]ClientState),
+		logins:  make(map[string]*clientLogin),
+	}
+}
+
+func (r *ClientRegistry) Get(jid string) *whatsmeow.Client {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.clients[jid]
+}
+
+func (r *ClientRegistry) Create(jid string, create func() *whatsmeow.Client) *whatsmeow.Client {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Return Existing Client So Concurrent Callers
+	// Always Share The Same Client for a JID
+	if client, ok := r.clients[jid]; ok {
+		return client
+	}
+
+	client := create()
+	r.clients[jid] = client
+	r.states[jid] = ClientStateInitializing
+
+	return client
+}
+
+func (r *ClientRegistry) Remove(jid string) {
+	r.mutex.Lock()
+	delete(r.clients, jid)
+	delete(r.states, jid)
+	r.mutex.Unlock()
+
+	// Forget Connection History Outside Registry Lock
+	// So Re-Paired Device Does Not Inherit Previous Status
+	WhatsAppStatusRemove(jid)
+}
+
+func (r *ClientRegistry) List() map[string]*whatsmeow.Client {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	// Return Snapshot of Registered Clients
+	// So Callers Can Iterate Without Holding The Lock
+	clients := make(map[string]*whatsmeow.Client, len(r.clients))
+	for jid, client := range r.clients {
+		clients[jid] = client
+	}
+
+	return clients
+}
+
+func (r *ClientRegistry) Len() int {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return len(r.clients)
+}
+
+func (r *ClientRegistry) GetState(jid string) ClientState {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	// Removed or Unknown Client Has No Session
+	if state, ok := r.states[jid]; ok {
+		return state
+	}
+
+	return ClientStateLoggedOut
+}
+
+func (r *ClientRegistry) SetState(jid string, state ClientState) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Only Track State for Registered Clients
+	if _, ok := r.clients[jid]; ok {
+		r.states[jid] = state
+	}
+}
+
+func (r *ClientRegistry) SwapState(jid string, from ClientState, to ClientState) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Only Change State if Current State is as Expected
+	if _, ok := r.clients[jid]; ok && r.states[jid] == from {
+		r.states[jid] = to
+		return true
+	}
+
+	return false
+}
+
+func (r *ClientRegistry) LockLogin(jid string) func() {
+	r.mutex.Lock()
+	login := r.logins[jid]
+	if login == nil {
+		login = &clientLogin{}
+		r.logins[jid] = login
+	}
+	login.refs++
+	r.mutex.Unlock()
+
+	// Serialize Login Process for The Same JID
+	login.mutex.Lock()
+
+	return func() {
+		login.mutex.Unlock()
+
+		// Remove Login Lock When No Other Caller is Waiting
+		r.mutex.Lock()
+		login.refs--
+		if login.refs == 0 {
+			delete(r.logins, jid)
+		}
+		r.mutex.Unlock()
+	}
+}
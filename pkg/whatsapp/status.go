@@ -0,0 +1,104 @@
+package whatsapp
+
+import (
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+type ClientStatus struct {
+	JID                string `json:"jid"`
+	IsExist            bool   `json:"is_exist"`
+	State              string `json:"state,omitempty"`
+	IsConnected        bool   `json:"is_connected"`
+	IsLoggedIn         bool   `json:"is_logged_in"`
+	DeviceJID          string `json:"device_jid,omitempty"`
+	PushName           string `json:"push_name,omitempty"`
+	Platform           string `json:"platform,omitempty"`
+	LastConnectedAt    int64  `json:"last_connected_at,omitempty"`
+	LastDisconnectedAt int64  `json:"last_disconnected_at,omitempty"`
+	LastError          string `json:"last_error,omitempty"`
+}
+
+var whatsAppStatusTracks = make(map[string]*ClientStatus)
+var whatsAppStatusMutex sync.RWMutex
+
+func WhatsAppStatusTrack(jid string, evt interface{}) {
+	whatsAppStatusMutex.Lock()
+	defer whatsAppStatusMutex.Unlock()
+
+	// Only Track Connection History for Registered Clients
+	// So Late Event from Removed Client Does Not Recreate It
+	if WhatsAppClient.Get(jid) == nil {
+		return
+	}
+
+	if whatsAppStatusTracks[jid] == nil {
+		whatsAppStatusTracks[jid] = &ClientStatus{JID: jid}
+	}
+	track := whatsAppStatusTracks[jid]
+
+	// Update Connection Status Based on Event Type
+	switch evtData := evt.(type) {
+	case *events.Connected:
+		WhatsAppClient.SetState(jid, ClientStateConnected)
+		track.LastConnectedAt = time.Now().Unix()
+		track.LastError = ""
+
+	case *events.Disconnected:
+		WhatsAppClient.SetState(jid, ClientStateDisconnected)
+		track.LastDisconnectedAt = time.Now().Unix()
+
+	case *events.LoggedOut:
+		WhatsAppClient.SetState(jid, ClientStateLoggedOut)
+		track.LastDisconnectedAt = time.Now().Unix()
+		track.LastError = "WhatsApp Client is Logged Out, " + evtData.Reason.String()
+
+	case *events.StreamReplaced:
+		WhatsAppClient.SetState(jid, ClientStateDisconnected)
+		track.LastDisconnectedAt = time.Now().Unix()
+		track.LastError = "WhatsApp Client Stream is Replaced by Another Connection"
+
+	case *events.ConnectFailure:
+		track.LastError = "WhatsApp Client Failed to Connect, " + evtData.Reason.String()
+	}
+}
+
+func WhatsAppStatusRemove(jid string) {
+	whatsAppStatusMutex.Lock()
+	defer whatsAppStatusMutex.Unlock()
+
+	delete(whatsAppStatusTracks, jid)
+}
+
+func WhatsAppStatusGet(jid string) ClientStatus {
+	status := ClientStatus{JID: jid}
+
+	// Get Tracked Connection History
+	whatsAppStatusMutex.RLock()
+	if track, ok := whatsAppStatusTracks[jid]; ok {
+		status = *track
+	}
+	whatsAppStatusMutex.RUnlock()
+
+	// Get WhatsApp Client Lifecycle State
+	status.State = string(WhatsAppClient.GetState(jid))
+
+	// Get Live WhatsApp Client Status
+	client := WhatsAppClient.Get(jid)
+	if client != nil {
+		status.IsExist = true
+		status.IsConnected = client.IsConnected()
+		status.IsLoggedIn = client.IsLoggedIn()
+
+		if client.Store.ID != nil {
+			status.DeviceJID = client.Store.ID.String()
+		}
+
+		status.PushName = client.Store.PushName
+		status.Platform = client.Store.Platform
+	}
+
+	return status
+}
@@ -0,0 +1,40 @@
+package whatsapp
+
+import (
+	"testing"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/store"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+func TestWhatsAppStatusRemove(t *testing.T) {
+	jid := "6281234567890"
+
+	WhatsAppClient.Create(jid, func() *whatsmeow.Client {
+		return &whatsmeow.Client{Store: &store.Device{}}
+	})
+
+	WhatsAppStatusTrack(jid, &events.ConnectFailure{})
+	if WhatsAppStatusGet(jid).LastError == "" {
+		t.Fatal("connection failure is not tracked for registered client")
+	}
+
+	WhatsAppClient.Remove(jid)
+	if status := WhatsAppStatusGet(jid); status.LastError != "" || status.IsExist {
+		t.Fatalf("removed client still has connection history: %+v", status)
+	}
+
+	// Late Event from Removed Client Should Not Recreate History
+	WhatsAppStatusTrack(jid, &events.Disconnected{})
+	if status := WhatsAppStatusGet(jid); status.LastDisconnectedAt != 0 {
+		t.Fatalf("removed client connection history is recreated: %+v", status)
+	}
+
+	whatsAppStatusMutex.RLock()
+	defer whatsAppStatusMutex.RUnlock()
+
+	if _, ok := whatsAppStatusTracks[jid]; ok {
+		t.Fatal("removed client is still tracked")
+	}
+}
@@ -0,0 +1,78 @@
+package whatsapp
+
+import (
+	"sync"
+)
+
+type EventSubscriber struct {
+	JID     string
+	Events  []string
+	Channel chan EventPayload
+}
+
+var whatsAppStreamSubscribers = make(map[string]map[*EventSubscriber]bool)
+var whatsAppStreamMutex sync.RWMutex
+
+func WhatsAppStreamSubscribe(jid string, events []string) *EventSubscriber {
+	subscriber := &EventSubscriber{
+		JID:     jid,
+		Events:  events,
+		Channel: make(chan EventPayload, 64),
+	}
+
+	whatsAppStreamMutex.Lock()
+	defer whatsAppStreamMutex.Unlock()
+
+	if whatsAppStreamSubscribers[jid] == nil {
+		whatsAppStreamSubscribers[jid] = make(map[*EventSubscriber]bool)
+	}
+	whatsAppStreamSubscribers[jid][subscriber] = true
+
+	return subscriber
+}
+
+func WhatsAppStreamUnsubscribe(subscriber *EventSubscriber) {
+	whatsAppStreamMutex.Lock()
+	defer whatsAppStreamMutex.Unlock()
+
+	if whatsAppStreamSubscribers[subscriber.JID] != nil {
+		delete(whatsAppStreamSubscribers[subscriber.JID], subscriber)
+
+		if len(whatsAppStreamSubscribers[subscriber.JID]) == 0 {
+			delete(whatsAppStreamSubscribers, subscriber.JID)
+		}
+	}
+}
+
+func WhatsAppStreamBroadcast(payload EventPayload) {
+	whatsAppStreamMutex.RLock()
+	defer whatsAppStreamMutex.RUnlock()
+
+	for subscriber := range whatsAppStreamSubscribers[payload.JID] {
+		if !WhatsAppStreamIsSubscribed(subscriber, payload.Event) {
+			continue
+		}
+
+		// Drop Event for Slow Subscriber
+		// So WhatsApp Client Event Queue is Not Blocked
+		select {
+		case subscriber.Channel <- payload:
+		default:
+		}
+	}
+}
+
+func WhatsAppStreamIsSubscribed(subscriber *EventSubscriber, event string) bool {
+	// Empty Event List Means Subscribe to All Events
+	if len(subscriber.Events) == 0 {
+		return true
+	}
+
+	for _, subscribed := range subscriber.Events {
+		if subscribed == event {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,342 @@
+package whatsapp
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/dimaskiddo/go-whatsapp-multidevice-rest/pkg/env"
+)
+
+type WebhookConfig struct {
+	JID       string   `json:"jid"`
+	URL       string   `json:"url"`
+	Secret    string   `json:"secret"`
+	Events    []string `json:"events"`
+	UpdatedAt int64    `json:"updated_at"`
+	IsGlobal  bool     `json:"-"`
+}
+
+var WhatsAppWebhookURL string
+var WhatsAppWebhookSecret string
+var WhatsAppWebhookTimeout int
+var WhatsAppWebhookAllowPrivateURL bool
+
+var whatsAppWebhookClient *http.Client
+var whatsAppWebhookPublicClient *http.Client
+
+type whatsAppWebhookCacheItem struct {
+	Webhook      WebhookConfig
+	IsConfigured bool
+	ExpiresAt    time.Time
+}
+
+const whatsAppWebhookCacheTTL = 30 * time.Second
+
+var whatsAppWebhookCache = make(map[string]whatsAppWebhookCacheItem)
+var whatsAppWebhookCacheMutex sync.RWMutex
+
+func init() {
+	var err error
+
+	WhatsAppWebhookURL, _ = env.GetEnvString("WHATSAPP_WEBHOOK_URL")
+	WhatsAppWebhookSecret, _ = env.GetEnvString("WHATSAPP_WEBHOOK_SECRET")
+
+	WhatsAppWebhookTimeout, err = env.GetEnvInt("WHATSAPP_WEBHOOK_TIMEOUT_SECONDS")
+	if err != nil || WhatsAppWebhookTimeout <= 0 {
+		WhatsAppWebhookTimeout = 10
+	}
+
+	// Device Webhook URL is Set by Authenticated Tenant
+	// So Only Public Address is Allowed Unless Explicitly Enabled
+	WhatsAppWebhookAllowPrivateURL, err = env.GetEnvBool("WHATSAPP_WEBHOOK_ALLOW_PRIVATE_URL")
+	if err != nil {
+		WhatsAppWebhookAllowPrivateURL = false
+	}
+
+	// Global Webhook URL is Set by Operator
+	// So It Can Point to Internal Network
+	whatsAppWebhookClient = &http.Client{
+		Timeout: time.Duration(WhatsAppWebhookTimeout) * time.Second,
+	}
+
+	// Check Resolved IP Address on Every Connection
+	// So Redirect or DNS Rebinding Cannot Reach Internal Network
+	webhookPublicDialer := &net.Dialer{
+		Timeout: time.Duration(WhatsAppWebhookTimeout) * time.Second,
+		Control: whatsAppWebhookDialControl,
+	}
+
+	// Connect Directly Without Proxy From Environment
+	// Since Proxy Would Make Dialer Only Check Proxy Address
+	webhookPublicTransport := http.DefaultTransport.(*http.Transport).Clone()
+	webhookPublicTransport.Proxy = nil
+	webhookPublicTransport.DialContext = webhookPublicDialer.DialContext
+
+	whatsAppWebhookPublicClient = &http.Client{
+		Timeout:   time.Duration(WhatsAppWebhookTimeout) * time.Second,
+		Transport: webhookPublicTransport,
+	}
+}
+
+func WhatsAppWebhookGet(jid string) (WebhookConfig, error) {
+	var webhook WebhookConfig
+	var webhookEvents string
+
+	// Get Webhook Configuration from Datastore
+	row := WhatsAppDatastoreDB.QueryRow(`SELECT jid, url, secret, events, updated_at FROM whatsapp_webhooks WHERE jid=$1`, jid)
+
+	err := row.Scan(&webhook.JID, &webhook.URL, &webhook.Secret, &webhookEvents, &webhook.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return webhook, errors.New("WhatsApp Webhook is Not Configured")
+		}
+
+		return webhook, err
+	}
+
+	webhook.Events = WhatsAppEventParseTypes(webhookEvents)
+	return webhook, nil
+}
+
+func WhatsAppWebhookSet(jid string, webhookURL string, webhookSecret string, webhookEvents []string) (WebhookConfig, error) {
+	var err error
+
+	// Make Sure Webhook URL is Valid HTTP or HTTPS URL
+	parsedURL, err := url.ParseRequestURI(webhookURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || len(parsedURL.Host) == 0 {
+		return WebhookConfig{}, errors.New("WhatsApp Webhook URL is Not Valid")
+	}
+
+	err = WhatsAppWebhookIsAllowed(parsedURL)
+	if err != nil {
+		return WebhookConfig{}, err
+	}
+
+	// Make Sure Every Subscribed Event Type is Known
+	for _, event := range webhookEvents {
+		if !WhatsAppEventIsValid(event) {
+			return WebhookConfig{}, errors.New("WhatsApp Webhook Event Type '" + event + "' is Not Valid")
+		}
+	}
+
+	// Generate Random Secret if Secret is Not Provided
+	if len(webhookSecret) == 0 {
+		webhookSecret, err = WhatsAppWebhookGenerateSecret()
+		if err != nil {
+			return WebhookConfig{}, err
+		}
+	}
+
+	webhook := WebhookConfig{
+		JID:       jid,
+		URL:       webhookURL,
+		Secret:    webhookSecret,
+		Events:    webhookEvents,
+		UpdatedAt: time.Now().Unix(),
+	}
+
+	// Save Webhook Configuration to Datastore
+	_, err = WhatsAppDatastoreDB.Exec(`INSERT INTO whatsapp_webhooks (jid, url, secret, events, updated_at) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (jid) DO UPDATE SET url=excluded.url, secret=excluded.secret, events=excluded.events, updated_at=excluded.updated_at`,
+		webhook.JID, webhook.URL, webhook.Secret, strings.Join(webhook.Events, ","), webhook.UpdatedAt)
+	if err != nil {
+		return WebhookConfig{}, err
+	}
+
+	whatsAppWebhookCacheInvalidate(jid)
+
+	return webhook, nil
+}
+
+func WhatsAppWebhookDelete(jid string) error {
+	// Delete Webhook Configuration from Datastore
+	res, err := WhatsAppDatastoreDB.Exec(`DELETE FROM whatsapp_webhooks WHERE jid=$1`, jid)
+	if err != nil {
+		return err
+	}
+
+	whatsAppWebhookCacheInvalidate(jid)
+
+	count, err := res.RowsAffected()
+	if err == nil && count == 0 {
+		return errors.New("WhatsApp Webhook is Not Configured")
+	}
+
+	return nil
+}
+
+func WhatsAppWebhookIsSubscribed(webhook WebhookConfig, event string) bool {
+	// Empty Event List Means Subscribe to All Events
+	if len(webhook.Events) == 0 {
+		return true
+	}
+
+	for _, subscribed := range webhook.Events {
+		if subscribed == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+func WhatsAppWebhookGenerateSecret() (string, error) {
+	secret := make([]byte, 32)
+
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
+
+func WhatsAppWebhookSignature(secret string, timestamp string, body []byte) string {
+	// Signature is HMAC-SHA256 of "<timestamp>.<body>"
+	// So Receivers Can Reject Forged or Replayed Deliveries
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func WhatsAppWebhookResolve(jid string) (WebhookConfig, bool) {
+	// Get Device Webhook Configuration
+	// Or Fallback to Global Webhook Configuration
+	webhook, err := WhatsAppWebhookGet(jid)
+	if err != nil {
+		webhook = WebhookConfig{
+			JID:      jid,
+			URL:      WhatsAppWebhookURL,
+			Secret:   WhatsAppWebhookSecret,
+			IsGlobal: true,
+		}
+	}
+
+	return webhook, len(webhook.URL) > 0
+}
+
+func whatsAppWebhookResolveCached(jid string) (WebhookConfig, bool) {
+	// Event Handler Resolve Webhook for Every Event
+	// So Keep Resolved Configuration Shortly in Memory
+	// Other Replicas Pick Up Changes When Cache Expires
+	whatsAppWebhookCacheMutex.RLock()
+	item, isExist := whatsAppWebhookCache[jid]
+	whatsAppWebhookCacheMutex.RUnlock()
+
+	if isExist && time.Now().Before(item.ExpiresAt) {
+		return item.Webhook, item.IsConfigured
+	}
+
+	webhook, isConfigured := WhatsAppWebhookResolve(jid)
+
+	whatsAppWebhookCacheMutex.Lock()
+	whatsAppWebhookCache[jid] = whatsAppWebhookCacheItem{
+		Webhook:      webhook,
+		IsConfigured: isConfigured,
+		ExpiresAt:    time.Now().Add(whatsAppWebhookCacheTTL),
+	}
+	whatsAppWebhookCacheMutex.Unlock()
+
+	return webhook, isConfigured
+}
+
+func whatsAppWebhookCacheInvalidate(jid string) {
+	whatsAppWebhookCacheMutex.Lock()
+	delete(whatsAppWebhookCache, jid)
+	whatsAppWebhookCacheMutex.Unlock()
+}
+
+func WhatsAppWebhookDeliver(webhook WebhookConfig, body []byte) error {
+	// Compose Webhook HTTP Request
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", WhatsAppUserAgentName)
+
+	// Sign Webhook HTTP Request if Secret is Available
+	if len(webhook.Secret) > 0 {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+		req.Header.Set("X-Timestamp", timestamp)
+		req.Header.Set("X-Signature", WhatsAppWebhookSignature(webhook.Secret, timestamp, body))
+	}
+
+	// Send Webhook HTTP Request
+	webhookClient := whatsAppWebhookClient
+	if !webhook.IsGlobal && !WhatsAppWebhookAllowPrivateURL {
+		webhookClient = whatsAppWebhookPublicClient
+	}
+
+	res, err := webhookClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// Make Sure Webhook Receiver Accepted The Event
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return errors.New("Webhook Receiver Responded with HTTP Status " + strconv.Itoa(res.StatusCode))
+	}
+
+	return nil
+}
+
+func WhatsAppWebhookIsAllowed(webhookURL *url.URL) error {
+	if WhatsAppWebhookAllowPrivateURL {
+		return nil
+	}
+
+	// Resolve Webhook Host When Saved So Tenant Gets Early Error
+	// Delivery Still Checks Every Connection Against DNS Rebinding
+	host := webhookURL.Hostname()
+
+	ips := []net.IP{net.ParseIP(host)}
+	if ips[0] == nil {
+		var err error
+
+		ips, err = net.LookupIP(host)
+		if err != nil || len(ips) == 0 {
+			return errors.New("WhatsApp Webhook URL Host " + host + " is Not Resolvable")
+		}
+	}
+
+	for _, ip := range ips {
+		if !WhatsAppMediaFetchIsPublicIP(ip) {
+			return errors.New("WhatsApp Webhook URL Host " + host + " is Not Allowed")
+		}
+	}
+
+	return nil
+}
+
+func whatsAppWebhookDialControl(network string, address string, conn syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !WhatsAppMediaFetchIsPublicIP(ip) {
+		return errors.New("WhatsApp Webhook URL Address " + host + " is Not Allowed")
+	}
+
+	return nil
+}
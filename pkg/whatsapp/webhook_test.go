@@ -0,0 +1,63 @@
+package whatsapp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestWhatsAppWebhookIsAllowed(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{name: "Public Address", url: "https://8.8.8.8/webhook"},
+		{name: "Public IPv6 Address", url: "https://[2001:4860:4860::8888]/webhook"},
+		{name: "Loopback", url: "http://127.0.0.1:8080/webhook", wantErr: true},
+		{name: "Loopback IPv6", url: "http://[::1]/webhook", wantErr: true},
+		{name: "Localhost", url: "http://localhost/webhook", wantErr: true},
+		{name: "Private", url: "http://10.0.0.1/webhook", wantErr: true},
+		{name: "Link-Local Metadata", url: "http://169.254.169.254/latest/meta-data", wantErr: true},
+		{name: "Carrier-Grade NAT", url: "http://100.64.0.1/webhook", wantErr: true},
+		{name: "Unspecified", url: "http://0.0.0.0/webhook", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhookURL, err := url.Parse(tt.url)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			err = WhatsAppWebhookIsAllowed(webhookURL)
+			if tt.wantErr && err == nil {
+				t.Fatal("expected error")
+			}
+
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestWhatsAppWebhookDeliverPrivateAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// Device Webhook Cannot Reach Loopback Receiver
+	err := WhatsAppWebhookDeliver(WebhookConfig{URL: server.URL}, []byte("{}"))
+	if err == nil {
+		t.Fatal("device webhook delivery to loopback address should be rejected")
+	}
+
+	// Operator Configured Global Webhook Can Reach Loopback Receiver
+	err = WhatsAppWebhookDeliver(WebhookConfig{URL: server.URL, IsGlobal: true}, []byte("{}"))
+	if err != nil {
+		t.Fatalf("global webhook delivery should be allowed: %v", err)
+	}
+}
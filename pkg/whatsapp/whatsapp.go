@@ -3,12 +3,13 @@ package whatsapp
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"encoding/base64"
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
-	webp "github.com/nickalie/go-webpbin"
 	"github.com/sunshineplan/imgconv"
 
 	qrCode "github.com/skip2/go-qrcode"
@@ -25,7 +26,9 @@ import (
 )
 
 var WhatsAppDatastore *sqlstore.Container
-var WhatsAppClient = make(map[string]*whatsmeow.Client)
+var WhatsAppDatastoreDB *sql.DB
+var WhatsAppDatastoreType string
+var WhatsAppClient = NewClientRegistry()
 
 var (
 	WhatsAppClientProxyURL string
@@ -46,7 +49,37 @@ func init() {
 		log.Print(nil).Fatal("Error Parse Environment Variable for WhatsApp Client Datastore URI")
 	}
 
-	datastore, err := sqlstore.New(dbType, dbURI, nil)
+	// SQLite Driver Only Understand Pragma Parameter
+	// So Make Sure Foreign Keys are Enabled for Every Connection
+	if dbType == "sqlite" && !strings.Contains(dbURI, "foreign_keys(") {
+		if strings.ContainsRune(dbURI, '?') {
+			dbURI = dbURI + "&_pragma=foreign_keys(1)"
+		} else {
+			dbURI = dbURI + "?_pragma=foreign_keys(1)"
+		}
+
+		log.Print(nil).Info("Enabling Foreign Keys Pragma on WhatsApp Client Datastore URI")
+	}
+
+	// Concurrent Writers Such as Webhook Outbox Workers
+	// Should Wait for The Lock Instead of Failing Immediately
+	if dbType == "sqlite" && !strings.Contains(dbURI, "busy_timeout(") {
+		if strings.ContainsRune(dbURI, '?') {
+			dbURI = dbURI + "&_pragma=busy_timeout(5000)"
+		} else {
+			dbURI = dbURI + "?_pragma=busy_timeout(5000)"
+		}
+
+		log.Print(nil).Info("Enabling Busy Timeout Pragma on WhatsApp Client Datastore URI")
+	}
+
+	datastoreDB, err := sql.Open(dbType, dbURI)
+	if err != nil {
+		log.Print(nil).Fatal("Error Connect WhatsApp Client Datastore")
+	}
+
+	datastore := sqlstore.NewWithDB(datastoreDB, dbType, nil)
+	err = datastore.Upgrade()
 	if err != nil {
 		log.Print(nil).Fatal("Error Connect WhatsApp Client Datastore")
 	}
@@ -64,12 +97,22 @@ func init() {
 	}
 
 	WhatsAppDatastore = datastore
+	WhatsAppDatastoreDB = datastoreDB
+	WhatsAppDatastoreType = dbType
+
+	// Upgrade Additional Tables in WhatsApp Client Datastore
+	err = WhatsAppDatastoreUpgrade()
+	if err != nil {
+		log.Print(nil).Fatal("Error Upgrade WhatsApp Client Datastore")
+	}
 }
 
 func WhatsAppInitClient(device *store.Device, jid string) {
-	var err error
+	// Initialize New WhatsApp Client Only if Not Exist
+	// Registry Lock Make Sure Concurrent Callers Cannot Both Create Client
+	WhatsAppClient.Create(jid, func() *whatsmeow.Client {
+		var err error
 
-	if WhatsAppClient[jid] == nil {
 		if device == nil {
 			// Initialize New WhatsApp Client Device in Datastore
 			device = WhatsAppDatastore.NewDevice()
@@ -95,20 +138,25 @@ func WhatsAppInitClient(device *store.Device, jid string) {
 		}
 
 		// Initialize New WhatsApp Client
-		// And Save it to The Map
-		WhatsAppClient[jid] = whatsmeow.NewClient(device, nil)
+		client := whatsmeow.NewClient(device, nil)
 
 		// Set WhatsApp Client Proxy Address if Proxy URL is Provided
 		if len(WhatsAppClientProxyURL) > 0 {
-			WhatsAppClient[jid].SetProxyAddress(WhatsAppClientProxyURL)
+			client.SetProxyAddress(WhatsAppClientProxyURL)
 		}
 
 		// Set WhatsApp Client Auto Reconnect
-		WhatsAppClient[jid].EnableAutoReconnect = true
+		client.EnableAutoReconnect = true
 
 		// Set WhatsApp Client Auto Trust Identity
-		WhatsAppClient[jid].AutoTrustIdentity = true
-	}
+		client.AutoTrustIdentity = true
+
+		// Set WhatsApp Client Event Handler
+		client.AddEventHandler(WhatsAppEventHandler(jid))
+
+		// Save it to The Registry
+		return client
+	})
 }
 
 func WhatsAppGetUserAgent(agentType string) waproto.DeviceProps_PlatformType {
@@ -150,49 +198,115 @@ func WhatsAppGetUserAgent(agentType string) waproto.DeviceProps_PlatformType {
 	}
 }
 
-func WhatsAppGenerateQR(qrChan <-chan whatsmeow.QRChannelItem) (string, int) {
-	qrChanCode := make(chan string)
-	qrChanTimeout := make(chan int)
+func WhatsAppGetPairClient(agentType string) (whatsmeow.PairClientType, string) {
+	switch strings.ToLower(agentType) {
+	case "edge":
+		return whatsmeow.PairClientEdge, "Edge (Windows)"
+	case "firefox":
+		return whatsmeow.PairClientFirefox, "Firefox (Linux)"
+	case "ie":
+		return whatsmeow.PairClientIE, "IE (Windows)"
+	case "opera":
+		return whatsmeow.PairClientOpera, "Opera (Linux)"
+	case "mac", "safari":
+		return whatsmeow.PairClientSafari, "Safari (Mac OS)"
+	default:
+		return whatsmeow.PairClientChrome, "Chrome (Linux)"
+	}
+}
+
+func WhatsAppWatchQR(ctx context.Context, jid string, qrChan <-chan whatsmeow.QRChannelItem) <-chan whatsmeow.QRChannelItem {
+	watchChan := make(chan whatsmeow.QRChannelItem)
+
+	// Set WhatsApp Client State to Pairing
+	WhatsAppClient.SetState(jid, ClientStatePairing)
 
-	// Get QR Code Data and Timeout
 	go func() {
+		defer close(watchChan)
+
 		for evt := range qrChan {
-			if evt.Event == "code" {
-				qrChanCode <- evt.Code
-				qrChanTimeout <- int(evt.Timeout.Seconds())
+			// Reset WhatsApp Client State When Pairing is Not Succeeded
+			// Successful Pairing State is Updated by Connected Event
+			if evt.Event != "code" && evt.Event != "success" {
+				WhatsAppClient.SwapState(jid, ClientStatePairing, ClientStateInitializing)
+			}
+
+			// Forward QR Code Event Until Reader is Gone
+			// Then Keep Draining The Source Channel
+			select {
+			case watchChan <- evt:
+			case <-ctx.Done():
 			}
 		}
 	}()
 
+	return watchChan
+}
+
+func WhatsAppGenerateQR(qrChan <-chan whatsmeow.QRChannelItem) (string, int) {
+	var qrTemp string
+	var qrTimeout int
+
+	// Get First QR Code Data and Timeout
+	for evt := range qrChan {
+		if evt.Event == "code" {
+			qrTemp = evt.Code
+			qrTimeout = int(evt.Timeout.Seconds())
+			break
+		}
+	}
+
+	// Drain Remaining QR Code Events in Background
+	// So The Channel Reader is Not Leaked
+	go func() {
+		for range qrChan {
+		}
+	}()
+
 	// Generate QR Code Data to PNG Image
-	qrTemp := <-qrChanCode
 	qrPNG, _ := qrCode.Encode(qrTemp, qrCode.Medium, 256)
 
 	// Return QR Code PNG in Base64 Format and Timeout Information
-	return base64.StdEncoding.EncodeToString(qrPNG), <-qrChanTimeout
+	return base64.StdEncoding.EncodeToString(qrPNG), qrTimeout
+}
+
+func WhatsAppEncodeQR(qrData string) (string, error) {
+	// Generate QR Code Data to PNG Image
+	qrPNG, err := qrCode.Encode(qrData, qrCode.Medium, 256)
+	if err != nil {
+		return "", err
+	}
+
+	// Return QR Code PNG in Base64 Format
+	return base64.StdEncoding.EncodeToString(qrPNG), nil
 }
 
 func WhatsAppLogin(jid string) (string, int, error) {
-	if WhatsAppClient[jid] != nil {
+	// Make Sure Only One Login Process is Running for The JID
+	unlockLogin := WhatsAppClient.LockLogin(jid)
+	defer unlockLogin()
+
+	client := WhatsAppClient.Get(jid)
+	if client != nil {
 		// Make Sure WebSocket Connection is Disconnected
-		WhatsAppClient[jid].Disconnect()
+		client.Disconnect()
 
-		if WhatsAppClient[jid].Store.ID == nil {
+		if client.Store.ID == nil {
 			// Device ID is not Exist
 			// Generate QR Code
-			qrChanGenerate, _ := WhatsAppClient[jid].GetQRChannel(context.Background())
+			qrChanGenerate, _ := client.GetQRChannel(context.Background())
 
 			// Connect WebSocket while Initialize QR Code Data to be Sent
-			err := WhatsAppClient[jid].Connect()
+			err := client.Connect()
 			if err != nil {
 				return "", 0, err
 			}
 
 			// Get Generated QR Code and Timeout Information
-			qrImage, qrTimeout := WhatsAppGenerateQR(qrChanGenerate)
+			qrImage, qrTimeout := WhatsAppGenerateQR(WhatsAppWatchQR(context.Background(), jid, qrChanGenerate))
 
 			// Set WhatsApp Client Presence to Available
-			_ = WhatsAppClient[jid].SendPresence(types.PresenceAvailable)
+			_ = client.SendPresence(types.PresenceAvailable)
 
 			// Return QR Code in Base64 Format and Timeout Information
 			return "data:image/png;base64," + qrImage, qrTimeout, nil
@@ -212,61 +326,181 @@ func WhatsAppLogin(jid string) (string, int, error) {
 	return "", 0, errors.New("WhatsApp Client is not Valid")
 }
 
-func WhatsAppReconnect(jid string) error {
-	if WhatsAppClient[jid] != nil {
+func WhatsAppLoginPair(jid string, phone string) (string, int, error) {
+	// Make Sure Only One Login Process is Running for The JID
+	unlockLogin := WhatsAppClient.LockLogin(jid)
+	defer unlockLogin()
+
+	client := WhatsAppClient.Get(jid)
+	if client != nil {
 		// Make Sure WebSocket Connection is Disconnected
-		WhatsAppClient[jid].Disconnect()
+		client.Disconnect()
 
-		// Make Sure Store ID is not Empty
-		// To do Reconnection
-		if WhatsAppClient[jid] != nil {
-			err := WhatsAppClient[jid].Connect()
+		if client.Store.ID == nil {
+			// Device ID is not Exist
+			// Generate QR Code Channel to Keep Login Timeout Semantics
+			qrChanGenerate, err := client.GetQRChannel(context.Background())
 			if err != nil {
-				return err
+				return "", 0, err
 			}
 
-			// Set WhatsApp Client Presence to Available
-			_ = WhatsAppClient[jid].SendPresence(types.PresenceAvailable)
+			// Connect WebSocket while Initialize QR Code Data to be Sent
+			err = client.Connect()
+			if err != nil {
+				return "", 0, err
+			}
 
-			return nil
-		}
+			// Watch QR Code Channel to Track Pairing State
+			qrChanGenerate = WhatsAppWatchQR(context.Background(), jid, qrChanGenerate)
+
+			// Wait Until First QR Code is Generated
+			// Pairing Code Can Only be Requested After That
+			// And Shares The Same Timeout as QR Code Login
+			var isReady bool
+			var pairTimeout int
+			for evt := range qrChanGenerate {
+				if evt.Event == "code" {
+					isReady = true
+					pairTimeout = int(evt.Timeout.Seconds())
+					break
+				}
+			}
+
+			// Drain Remaining QR Code Events in Background
+			// Channel is Closed and WebSocket Disconnected When Pairing Timeout
+			go func() {
+				for range qrChanGenerate {
+				}
+			}()
+
+			if !isReady {
+				return "", 0, errors.New("WhatsApp Client Failed to Prepare Pairing Code")
+			}
+
+			// Request Pairing Code for Phone Number
+			pairClientType, pairClientName := WhatsAppGetPairClient(WhatsAppUserAgentType)
+
+			pairCode, err := client.PairPhone(WhatsAppDecomposeJID(phone), true, pairClientType, pairClientName)
+			if err != nil {
+				client.Disconnect()
+				return "", 0, err
+			}
 
-		return errors.New("WhatsApp Client Store ID is Empty, Please Re-Login and Scan QR Code Again")
+			// Return Pairing Code and Timeout Information
+			return pairCode, pairTimeout, nil
+		} else {
+			// Device ID is Exist
+			// Reconnect WebSocket
+			err := WhatsAppReconnect(jid)
+			if err != nil {
+				return "", 0, err
+			}
+
+			return "WhatsApp Client is Reconnected", 0, nil
+		}
 	}
 
-	return errors.New("WhatsApp Client is not Valid")
+	// Return Error WhatsApp Client is not Valid
+	return "", 0, errors.New("WhatsApp Client is not Valid")
 }
 
-func WhatsAppLogout(jid string) error {
-	if WhatsAppClient[jid] != nil {
-		// Make Sure Store ID is not Empty
-		if WhatsAppClient[jid] != nil {
-			var err error
+func WhatsAppLoginStream(ctx context.Context, jid string) (<-chan whatsmeow.QRChannelItem, error) {
+	// Make Sure Only One Login Process is Running for The JID
+	unlockLogin := WhatsAppClient.LockLogin(jid)
+	defer unlockLogin()
 
-			// Set WhatsApp Client Presence to Unavailable
-			_ = WhatsAppClient[jid].SendPresence(types.PresenceUnavailable)
+	client := WhatsAppClient.Get(jid)
+	if client != nil {
+		// Make Sure WebSocket Connection is Disconnected
+		client.Disconnect()
 
-			// Logout WhatsApp Client and Disconnect from WebSocket
-			err = WhatsAppClient[jid].Logout()
+		if client.Store.ID == nil {
+			// Device ID is not Exist
+			// Generate QR Code Channel Bound to Request Context
+			qrChanGenerate, err := client.GetQRChannel(ctx)
 			if err != nil {
-				// Force Disconnect
-				WhatsAppClient[jid].Disconnect()
+				return nil, err
+			}
 
-				// Manually Delete Device from Datastore Store
-				err = WhatsAppClient[jid].Store.Delete()
-				if err != nil {
-					return err
-				}
+			// Connect WebSocket while Initialize QR Code Data to be Sent
+			err = client.Connect()
+			if err != nil {
+				return nil, err
 			}
 
-			// Free WhatsApp Client Map
-			WhatsAppClient[jid] = nil
-			delete(WhatsAppClient, jid)
+			// Return Watched QR Code Channel
+			return WhatsAppWatchQR(ctx, jid, qrChanGenerate), nil
+		} else {
+			// Device ID is Exist
+			// Reconnect WebSocket and Return Empty QR Code Channel
+			err := WhatsAppReconnect(jid)
+			if err != nil {
+				return nil, err
+			}
+
+			return nil, nil
+		}
+	}
+
+	// Return Error WhatsApp Client is not Valid
+	return nil, errors.New("WhatsApp Client is not Valid")
+}
+
+func WhatsAppReconnect(jid string) error {
+	client := WhatsAppClient.Get(jid)
+	if client != nil {
+		// Make Sure WebSocket Connection is Disconnected
+		client.Disconnect()
 
-			return nil
+		// Make Sure Store ID is not Empty
+		// To do Reconnection
+		if client.Store.ID == nil {
+			return errors.New("WhatsApp Client Store ID is Empty, Please Re-Login and Scan QR Code Again")
+		}
+
+		err := client.Connect()
+		if err != nil {
+			return err
 		}
 
-		return errors.New("WhatsApp Client Store ID is Empty, Please Re-Login and Scan QR Code Again")
+		// Set WhatsApp Client Presence to Available
+		_ = client.SendPresence(types.PresenceAvailable)
+
+		return nil
+	}
+
+	return errors.New("WhatsApp Client is not Valid")
+}
+
+func WhatsAppLogout(jid string) error {
+	// Make Sure Logout is Not Running Together with Login Process for The JID
+	unlockLogin := WhatsAppClient.LockLogin(jid)
+	defer unlockLogin()
+
+	client := WhatsAppClient.Get(jid)
+	if client != nil {
+		var err error
+
+		// Set WhatsApp Client Presence to Unavailable
+		_ = client.SendPresence(types.PresenceUnavailable)
+
+		// Logout WhatsApp Client and Disconnect from WebSocket
+		err = client.Logout()
+		if err != nil {
+			// Force Disconnect
+			client.Disconnect()
+
+			// Manually Delete Device from Datastore Store
+			err = client.Store.Delete()
+			if err != nil {
+				return err
+			}
+		}
+
+		// Remove WhatsApp Client from Registry
+		WhatsAppClient.Remove(jid)
+
+		return nil
 	}
 
 	// Return Error WhatsApp Client is not Valid
@@ -274,13 +508,20 @@ func WhatsAppLogout(jid string) error {
 }
 
 func WhatsAppIsClientOK(jid string) error {
+	client := WhatsAppClient.Get(jid)
+
+	// Make Sure WhatsApp Client is Valid
+	if client == nil {
+		return errors.New("WhatsApp Client is not Valid")
+	}
+
 	// Make Sure WhatsApp Client is Connected
-	if !WhatsAppClient[jid].IsConnected() {
+	if !client.IsConnected() {
 		return errors.New("WhatsApp Client is not Connected")
 	}
 
 	// Make Sure WhatsApp Client is Logged In
-	if !WhatsAppClient[jid].IsLoggedIn() {
+	if !client.IsLoggedIn() {
 		return errors.New("WhatsApp Client is not Logged In")
 	}
 
@@ -288,11 +529,12 @@ func WhatsAppIsClientOK(jid string) error {
 }
 
 func WhatsAppGetJID(jid string, id string) types.JID {
-	if WhatsAppClient[jid] != nil {
+	client := WhatsAppClient.Get(jid)
+	if client != nil {
 		var ids []string
 
 		ids = append(ids, "+"+id)
-		infos, err := WhatsAppClient[jid].IsOnWhatsApp(ids)
+		infos, err := client.IsOnWhatsApp(ids)
 		if err == nil {
 			// If WhatsApp ID is Registered Then
 			// Return ID Information
@@ -357,11 +599,17 @@ func WhatsAppComposeStatus(jid string, rjid types.JID, isComposing bool, isAudio
 	}
 
 	// Send Chat Compose Status
-	_ = WhatsAppClient[jid].SendChatPresence(rjid, typeCompose, typeComposeMedia)
+	client := WhatsAppClient.Get(jid)
+	if client == nil {
+		return
+	}
+
+	_ = client.SendChatPresence(rjid, typeCompose, typeComposeMedia)
 }
 
-func WhatsAppSendText(ctx context.Context, jid string, rjid string, message string) (string, error) {
-	if WhatsAppClient[jid] != nil {
+func WhatsAppSendText(ctx context.Context, jid string, rjid string, message string, msgOptions SendOptions) (string, error) {
+	client := WhatsAppClient.Get(jid)
+	if client != nil {
 		var err error
 
 		// Make Sure WhatsApp Client is OK
@@ -389,7 +637,7 @@ func WhatsAppSendText(ctx context.Context, jid string, rjid string, message stri
 		}
 
 		// Send WhatsApp Message Proto
-		_, err = WhatsAppClient[jid].SendMessage(ctx, remoteJID, msgContent, msgExtra)
+		err = WhatsAppSendMessage(ctx, jid, remoteJID, msgContent, msgExtra, msgOptions)
 		if err != nil {
 			return "", err
 		}
@@ -401,8 +649,9 @@ func WhatsAppSendText(ctx context.Context, jid string, rjid string, message stri
 	return "", errors.New("WhatsApp Client is not Valid")
 }
 
-func WhatsAppSendLocation(ctx context.Context, jid string, rjid string, latitude float64, longitude float64) (string, error) {
-	if WhatsAppClient[jid] != nil {
+func WhatsAppSendLocation(ctx context.Context, jid string, rjid string, latitude float64, longitude float64, locationDetail LocationDetail, msgOptions SendOptions) (string, error) {
+	client := WhatsAppClient.Get(jid)
+	if client != nil {
 		var err error
 
 		// Make Sure WhatsApp Client is OK
@@ -411,6 +660,11 @@ func WhatsAppSendLocation(ctx context.Context, jid string, rjid string, latitude
 			return "", err
 		}
 
+		err = WhatsAppLocationValidate(latitude, longitude)
+		if err != nil {
+			return "", err
+		}
+
 		// Compose New Remote JID
 		remoteJID := WhatsAppComposeJID(rjid)
 		if WhatsAppGetJID(jid, remoteJID.String()).IsEmpty() {
@@ -432,8 +686,27 @@ func WhatsAppSendLocation(ctx context.Context, jid string, rjid string, latitude
 			},
 		}
 
+		if len(locationDetail.Name) > 0 {
+			msgContent.LocationMessage.Name = proto.String(locationDetail.Name)
+		}
+
+		if len(locationDetail.Address) > 0 {
+			msgContent.LocationMessage.Address = proto.String(locationDetail.Address)
+		}
+
+		if len(locationDetail.URL) > 0 {
+			msgContent.LocationMessage.URL = proto.String(locationDetail.URL)
+		}
+
+		if len(locationDetail.Thumbnail) > 0 {
+			msgContent.LocationMessage.JPEGThumbnail, err = WhatsAppLocationThumbnail(locationDetail.Thumbnail)
+			if err != nil {
+				return "", err
+			}
+		}
+
 		// Send WhatsApp Message Proto
-		_, err = WhatsAppClient[jid].SendMessage(ctx, remoteJID, msgContent, msgExtra)
+		err = WhatsAppSendMessage(ctx, jid, remoteJID, msgContent, msgExtra, msgOptions)
 		if err != nil {
 			return "", err
 		}
@@ -445,8 +718,9 @@ func WhatsAppSendLocation(ctx context.Context, jid string, rjid string, latitude
 	return "", errors.New("WhatsApp Client is not Valid")
 }
 
-func WhatsAppSendDocument(ctx context.Context, jid string, rjid string, fileBytes []byte, fileType string, fileName string) (string, error) {
-	if WhatsAppClient[jid] != nil {
+func WhatsAppSendDocument(ctx context.Context, jid string, rjid string, fileBytes []byte, fileType string, fileName string, fileCaption string, msgOptions SendOptions) (string, error) {
+	client := WhatsAppClient.Get(jid)
+	if client != nil {
 		var err error
 
 		// Make Sure WhatsApp Client is OK
@@ -461,12 +735,36 @@ func WhatsAppSendDocument(ctx context.Context, jid string, rjid string, fileByte
 			return "", errors.New("WhatsApp Personal ID is Not Registered")
 		}
 
+		// Resolve Send Options Before Processing Media
+		// So Invalid Reply or Mention Does Not Waste Upload
+		msgOptions, err = WhatsAppResolveSendOptions(jid, remoteJID, fileCaption, msgOptions)
+		if err != nil {
+			return "", err
+		}
+
 		// Set Chat Presence
 		WhatsAppComposeStatus(jid, remoteJID, true, false)
 		defer WhatsAppComposeStatus(jid, remoteJID, false, false)
 
+		// Make Sure Document Has Proper MIME Type
+		// So Client Can Show Document Icon Based on It
+		fileType = WhatsAppDocumentMimeType(fileType, fileName)
+
+		// Get PDF Page Count and First Page Thumbnail
+		// Document is Still Sent Without Preview When Not Available
+		var filePreview DocumentPreview
+
+		if WhatsAppDocumentIsPDF(fileBytes, fileType) {
+			fileType = "application/pdf"
+
+			filePreview, err = WhatsAppDocumentPDFPreview(ctx, fileBytes)
+			if err != nil {
+				log.Print(nil).Warn("Failed to Create Document Preview, " + err.Error())
+			}
+		}
+
 		// Upload File to WhatsApp Storage Server
-		fileUploaded, err := WhatsAppClient[jid].Upload(ctx, fileBytes, whatsmeow.MediaDocument)
+		fileUploaded, err := client.Upload(ctx, fileBytes, whatsmeow.MediaDocument)
 		if err != nil {
 			return "", errors.New("Error While Uploading Media to WhatsApp Server")
 		}
@@ -477,24 +775,57 @@ func WhatsAppSendDocument(ctx context.Context, jid string, rjid string, fileByte
 		}
 		msgContent := &waproto.Message{
 			DocumentMessage: &waproto.DocumentMessage{
-				Url:           proto.String(fileUploaded.URL),
+				URL:           proto.String(fileUploaded.URL),
 				DirectPath:    proto.String(fileUploaded.DirectPath),
 				Mimetype:      proto.String(fileType),
 				Title:         proto.String(fileName),
 				FileName:      proto.String(fileName),
 				FileLength:    proto.Uint64(fileUploaded.FileLength),
-				FileSha256:    fileUploaded.FileSHA256,
-				FileEncSha256: fileUploaded.FileEncSHA256,
+				FileSHA256:    fileUploaded.FileSHA256,
+				FileEncSHA256: fileUploaded.FileEncSHA256,
 				MediaKey:      fileUploaded.MediaKey,
 			},
 		}
 
+		if filePreview.PageCount > 0 {
+			msgContent.DocumentMessage.PageCount = proto.Uint32(filePreview.PageCount)
+		}
+
+		if len(filePreview.Thumbnail) > 0 {
+			// Upload Document Thumbnail to WhatsApp Storage Server
+			fileThumbUploaded, err := client.Upload(ctx, filePreview.Thumbnail, whatsmeow.MediaLinkThumbnail)
+			if err != nil {
+				return "", errors.New("Error while Uploading Document Thumbnail to WhatsApp Server")
+			}
+
+			msgContent.DocumentMessage.JPEGThumbnail = filePreview.Thumbnail
+			msgContent.DocumentMessage.ThumbnailDirectPath = &fileThumbUploaded.DirectPath
+			msgContent.DocumentMessage.ThumbnailSHA256 = fileThumbUploaded.FileSHA256
+			msgContent.DocumentMessage.ThumbnailEncSHA256 = fileThumbUploaded.FileEncSHA256
+			msgContent.DocumentMessage.ThumbnailWidth = proto.Uint32(filePreview.ThumbnailWidth)
+			msgContent.DocumentMessage.ThumbnailHeight = proto.Uint32(filePreview.ThumbnailHeight)
+		}
+
+		// Recipient Client Only Shows Document Caption
+		// When Document is Wrapped Inside Document With Caption Message
+		if len(fileCaption) > 0 {
+			msgContent.DocumentMessage.Caption = proto.String(fileCaption)
+			msgContent = &waproto.Message{
+				DocumentWithCaptionMessage: &waproto.FutureProofMessage{
+					Message: msgContent,
+				},
+			}
+		}
+
 		// Send WhatsApp Message Proto
-		_, err = WhatsAppClient[jid].SendMessage(ctx, remoteJID, msgContent, msgExtra)
+		err = WhatsAppSendMessage(ctx, jid, remoteJID, msgContent, msgExtra, msgOptions)
 		if err != nil {
 			return "", err
 		}
 
+		// Cache Sent Media to Media Store
+		WhatsAppMediaCache(jid, msgExtra.ID, fileBytes, fileType, fileName)
+
 		return msgExtra.ID, nil
 	}
 
@@ -502,8 +833,9 @@ func WhatsAppSendDocument(ctx context.Context, jid string, rjid string, fileByte
 	return "", errors.New("WhatsApp Client is not Valid")
 }
 
-func WhatsAppSendImage(ctx context.Context, jid string, rjid string, imageBytes []byte, imageType string, imageCaption string, isViewOnce bool) (string, error) {
-	if WhatsAppClient[jid] != nil {
+func WhatsAppSendImage(ctx context.Context, jid string, rjid string, imageBytes []byte, imageType string, imageCaption string, isViewOnce bool, msgOptions SendOptions) (string, error) {
+	client := WhatsAppClient.Get(jid)
+	if client != nil {
 		var err error
 
 		// Make Sure WhatsApp Client is OK
@@ -518,6 +850,13 @@ func WhatsAppSendImage(ctx context.Context, jid string, rjid string, imageBytes
 			return "", errors.New("WhatsApp Personal ID is Not Registered")
 		}
 
+		// Resolve Send Options Before Processing Media
+		// So Invalid Reply or Mention Does Not Waste Upload
+		msgOptions, err = WhatsAppResolveSendOptions(jid, remoteJID, imageCaption, msgOptions)
+		if err != nil {
+			return "", err
+		}
+
 		// Set Chat Presence
 		WhatsAppComposeStatus(jid, remoteJID, true, false)
 		defer WhatsAppComposeStatus(jid, remoteJID, false, false)
@@ -590,13 +929,13 @@ func WhatsAppSendImage(ctx context.Context, jid string, rjid string, imageBytes
 		}
 
 		// Upload Image to WhatsApp Storage Server
-		imageUploaded, err := WhatsAppClient[jid].Upload(ctx, imageBytes, whatsmeow.MediaImage)
+		imageUploaded, err := client.Upload(ctx, imageBytes, whatsmeow.MediaImage)
 		if err != nil {
 			return "", errors.New("Error While Uploading Media to WhatsApp Server")
 		}
 
 		// Upload Image Thumbnail to WhatsApp Storage Server
-		imageThumbUploaded, err := WhatsAppClient[jid].Upload(ctx, imgThumbEncode.Bytes(), whatsmeow.MediaLinkThumbnail)
+		imageThumbUploaded, err := client.Upload(ctx, imgThumbEncode.Bytes(), whatsmeow.MediaLinkThumbnail)
 		if err != nil {
 			return "", errors.New("Error while Uploading Image Thumbnail to WhatsApp Server")
 		}
@@ -607,28 +946,31 @@ func WhatsAppSendImage(ctx context.Context, jid string, rjid string, imageBytes
 		}
 		msgContent := &waproto.Message{
 			ImageMessage: &waproto.ImageMessage{
-				Url:                 proto.String(imageUploaded.URL),
+				URL:                 proto.String(imageUploaded.URL),
 				DirectPath:          proto.String(imageUploaded.DirectPath),
 				Mimetype:            proto.String(imageType),
 				Caption:             proto.String(imageCaption),
 				FileLength:          proto.Uint64(imageUploaded.FileLength),
-				FileSha256:          imageUploaded.FileSHA256,
-				FileEncSha256:       imageUploaded.FileEncSHA256,
+				FileSHA256:          imageUploaded.FileSHA256,
+				FileEncSHA256:       imageUploaded.FileEncSHA256,
 				MediaKey:            imageUploaded.MediaKey,
-				JpegThumbnail:       imgThumbEncode.Bytes(),
+				JPEGThumbnail:       imgThumbEncode.Bytes(),
 				ThumbnailDirectPath: &imageThumbUploaded.DirectPath,
-				ThumbnailSha256:     imageThumbUploaded.FileSHA256,
-				ThumbnailEncSha256:  imageThumbUploaded.FileEncSHA256,
+				ThumbnailSHA256:     imageThumbUploaded.FileSHA256,
+				ThumbnailEncSHA256:  imageThumbUploaded.FileEncSHA256,
 				ViewOnce:            proto.Bool(isViewOnce),
 			},
 		}
 
 		// Send WhatsApp Message Proto
-		_, err = WhatsAppClient[jid].SendMessage(ctx, remoteJID, msgContent, msgExtra)
+		err = WhatsAppSendMessage(ctx, jid, remoteJID, msgContent, msgExtra, msgOptions)
 		if err != nil {
 			return "", err
 		}
 
+		// Cache Sent Media to Media Store
+		WhatsAppMediaCache(jid, msgExtra.ID, imageBytes, msgContent.GetImageMessage().GetMimetype(), "")
+
 		return msgExtra.ID, nil
 	}
 
@@ -636,8 +978,9 @@ func WhatsAppSendImage(ctx context.Context, jid string, rjid string, imageBytes
 	return "", errors.New("WhatsApp Client is not Valid")
 }
 
-func WhatsAppSendAudio(ctx context.Context, jid string, rjid string, audioBytes []byte, audioType string) (string, error) {
-	if WhatsAppClient[jid] != nil {
+func WhatsAppSendAudio(ctx context.Context, jid string, rjid string, audioBytes []byte, audioType string, isPTT bool, msgOptions SendOptions) (string, error) {
+	client := WhatsAppClient.Get(jid)
+	if client != nil {
 		var err error
 
 		// Make Sure WhatsApp Client is OK
@@ -652,12 +995,36 @@ func WhatsAppSendAudio(ctx context.Context, jid string, rjid string, audioBytes
 			return "", errors.New("WhatsApp Personal ID is Not Registered")
 		}
 
+		// Resolve Send Options Before Processing Media
+		// So Invalid Reply or Mention Does Not Waste Upload
+		msgOptions, err = WhatsAppResolveSendOptions(jid, remoteJID, "", msgOptions)
+		if err != nil {
+			return "", err
+		}
+
 		// Set Chat Presence
 		WhatsAppComposeStatus(jid, remoteJID, true, true)
 		defer WhatsAppComposeStatus(jid, remoteJID, false, true)
 
+		// Voice Note Needs OGG/Opus Audio with Duration and Waveform
+		// To be Rendered as Native Voice Message
+		var audioSeconds uint32
+		var audioWaveform []byte
+
+		if isPTT {
+			voiceNote, err := WhatsAppAudioVoiceNote(ctx, audioBytes)
+			if err != nil {
+				return "", err
+			}
+
+			audioBytes = voiceNote.Bytes
+			audioType = voiceNote.MimeType
+			audioSeconds = voiceNote.Seconds
+			audioWaveform = voiceNote.Waveform
+		}
+
 		// Upload Audio to WhatsApp Storage Server
-		audioUploaded, err := WhatsAppClient[jid].Upload(ctx, audioBytes, whatsmeow.MediaAudio)
+		audioUploaded, err := client.Upload(ctx, audioBytes, whatsmeow.MediaAudio)
 		if err != nil {
 			return "", errors.New("Error While Uploading Media to WhatsApp Server")
 		}
@@ -668,22 +1035,31 @@ func WhatsAppSendAudio(ctx context.Context, jid string, rjid string, audioBytes
 		}
 		msgContent := &waproto.Message{
 			AudioMessage: &waproto.AudioMessage{
-				Url:           proto.String(audioUploaded.URL),
+				URL:           proto.String(audioUploaded.URL),
 				DirectPath:    proto.String(audioUploaded.DirectPath),
 				Mimetype:      proto.String(audioType),
 				FileLength:    proto.Uint64(audioUploaded.FileLength),
-				FileSha256:    audioUploaded.FileSHA256,
-				FileEncSha256: audioUploaded.FileEncSHA256,
+				FileSHA256:    audioUploaded.FileSHA256,
+				FileEncSHA256: audioUploaded.FileEncSHA256,
 				MediaKey:      audioUploaded.MediaKey,
+				PTT:           proto.Bool(isPTT),
 			},
 		}
 
+		if isPTT {
+			msgContent.AudioMessage.Seconds = proto.Uint32(audioSeconds)
+			msgContent.AudioMessage.Waveform = audioWaveform
+		}
+
 		// Send WhatsApp Message Proto
-		_, err = WhatsAppClient[jid].SendMessage(ctx, remoteJID, msgContent, msgExtra)
+		err = WhatsAppSendMessage(ctx, jid, remoteJID, msgContent, msgExtra, msgOptions)
 		if err != nil {
 			return "", err
 		}
 
+		// Cache Sent Media to Media Store
+		WhatsAppMediaCache(jid, msgExtra.ID, audioBytes, msgContent.GetAudioMessage().GetMimetype(), "")
+
 		return msgExtra.ID, nil
 	}
 
@@ -691,8 +1067,9 @@ func WhatsAppSendAudio(ctx context.Context, jid string, rjid string, audioBytes
 	return "", errors.New("WhatsApp Client is not Valid")
 }
 
-func WhatsAppSendVideo(ctx context.Context, jid string, rjid string, videoBytes []byte, videoType string, videoCaption string, isViewOnce bool) (string, error) {
-	if WhatsAppClient[jid] != nil {
+func WhatsAppSendVideo(ctx context.Context, jid string, rjid string, videoBytes []byte, videoType string, videoCaption string, isViewOnce bool, videoGIF *VideoGIF, msgOptions SendOptions) (string, error) {
+	client := WhatsAppClient.Get(jid)
+	if client != nil {
 		var err error
 
 		// Make Sure WhatsApp Client is OK
@@ -707,12 +1084,33 @@ func WhatsAppSendVideo(ctx context.Context, jid string, rjid string, videoBytes
 			return "", errors.New("WhatsApp Personal ID is Not Registered")
 		}
 
+		// Resolve Send Options Before Processing Media
+		// So Invalid Reply or Mention Does Not Waste Upload
+		msgOptions, err = WhatsAppResolveSendOptions(jid, remoteJID, videoCaption, msgOptions)
+		if err != nil {
+			return "", err
+		}
+
 		// Set Chat Presence
 		WhatsAppComposeStatus(jid, remoteJID, true, false)
 		defer WhatsAppComposeStatus(jid, remoteJID, false, false)
 
+		// Get Video Duration and Dimensions from MP4 Boxes
+		// Video is Still Sent Without Metadata When Not Parsable
+		videoMetadata, err := WhatsAppVideoMetadata(videoBytes)
+		if err != nil {
+			log.Print(nil).Warn("Failed to Parse Video Metadata, " + err.Error())
+		}
+
+		// Creating Video JPEG Thumbnail from Poster Frame
+		// Video is Still Sent Without Thumbnail When Frame Cannot be Extracted
+		videoThumbBytes, err := WhatsAppVideoThumbnail(ctx, videoBytes, videoMetadata.Seconds)
+		if err != nil {
+			log.Print(nil).Warn("Failed to Create Video Thumbnail, " + err.Error())
+		}
+
 		// Upload Video to WhatsApp Storage Server
-		videoUploaded, err := WhatsAppClient[jid].Upload(ctx, videoBytes, whatsmeow.MediaVideo)
+		videoUploaded, err := client.Upload(ctx, videoBytes, whatsmeow.MediaVideo)
 		if err != nil {
 			return "", errors.New("Error While Uploading Media to WhatsApp Server")
 		}
@@ -723,24 +1121,55 @@ func WhatsAppSendVideo(ctx context.Context, jid string, rjid string, videoBytes
 		}
 		msgContent := &waproto.Message{
 			VideoMessage: &waproto.VideoMessage{
-				Url:           proto.String(videoUploaded.URL),
+				URL:           proto.String(videoUploaded.URL),
 				DirectPath:    proto.String(videoUploaded.DirectPath),
 				Mimetype:      proto.String(videoType),
 				Caption:       proto.String(videoCaption),
 				FileLength:    proto.Uint64(videoUploaded.FileLength),
-				FileSha256:    videoUploaded.FileSHA256,
-				FileEncSha256: videoUploaded.FileEncSHA256,
+				FileSHA256:    videoUploaded.FileSHA256,
+				FileEncSHA256: videoUploaded.FileEncSHA256,
 				MediaKey:      videoUploaded.MediaKey,
 				ViewOnce:      proto.Bool(isViewOnce),
 			},
 		}
 
+		// GIF is Played as Looping Muted Video
+		if videoGIF != nil {
+			msgContent.VideoMessage.GifPlayback = proto.Bool(true)
+			msgContent.VideoMessage.GifAttribution = videoGIF.Attribution.Enum()
+		}
+
+		if videoMetadata.Seconds > 0 {
+			msgContent.VideoMessage.Seconds = proto.Uint32(videoMetadata.Seconds)
+		}
+
+		if videoMetadata.Width > 0 && videoMetadata.Height > 0 {
+			msgContent.VideoMessage.Width = proto.Uint32(videoMetadata.Width)
+			msgContent.VideoMessage.Height = proto.Uint32(videoMetadata.Height)
+		}
+
+		if len(videoThumbBytes) > 0 {
+			// Upload Video Thumbnail to WhatsApp Storage Server
+			videoThumbUploaded, err := client.Upload(ctx, videoThumbBytes, whatsmeow.MediaLinkThumbnail)
+			if err != nil {
+				return "", errors.New("Error while Uploading Video Thumbnail to WhatsApp Server")
+			}
+
+			msgContent.VideoMessage.JPEGThumbnail = videoThumbBytes
+			msgContent.VideoMessage.ThumbnailDirectPath = &videoThumbUploaded.DirectPath
+			msgContent.VideoMessage.ThumbnailSHA256 = videoThumbUploaded.FileSHA256
+			msgContent.VideoMessage.ThumbnailEncSHA256 = videoThumbUploaded.FileEncSHA256
+		}
+
 		// Send WhatsApp Message Proto
-		_, err = WhatsAppClient[jid].SendMessage(ctx, remoteJID, msgContent, msgExtra)
+		err = WhatsAppSendMessage(ctx, jid, remoteJID, msgContent, msgExtra, msgOptions)
 		if err != nil {
 			return "", err
 		}
 
+		// Cache Sent Media to Media Store
+		WhatsAppMediaCache(jid, msgExtra.ID, videoBytes, msgContent.GetVideoMessage().GetMimetype(), "")
+
 		return msgExtra.ID, nil
 	}
 
@@ -748,8 +1177,26 @@ func WhatsAppSendVideo(ctx context.Context, jid string, rjid string, videoBytes
 	return "", errors.New("WhatsApp Client is not Valid")
 }
 
-func WhatsAppSendContact(ctx context.Context, jid string, rjid string, contactName string, contactNumber string) (string, error) {
-	if WhatsAppClient[jid] != nil {
+func WhatsAppSendGIF(ctx context.Context, jid string, rjid string, gifBytes []byte, gifType string, gifCaption string, gifAttribution string, msgOptions SendOptions) (string, error) {
+	// Make Sure GIF Attribution is Valid
+	videoAttribution, err := WhatsAppVideoGIFAttribution(gifAttribution)
+	if err != nil {
+		return "", err
+	}
+
+	// Convert GIF Image or Short MP4 Video to Muted MP4 Video
+	// Then Send it as Video with GIF Playback
+	videoBytes, err := WhatsAppVideoGIF(ctx, gifBytes, gifType)
+	if err != nil {
+		return "", err
+	}
+
+	return WhatsAppSendVideo(ctx, jid, rjid, videoBytes, "video/mp4", gifCaption, false, &VideoGIF{Attribution: videoAttribution}, msgOptions)
+}
+
+func WhatsAppSendContact(ctx context.Context, jid string, rjid string, contactName string, contactNumber string, msgOptions SendOptions) (string, error) {
+	client := WhatsAppClient.Get(jid)
+	if client != nil {
 		var err error
 
 		// Make Sure WhatsApp Client is OK
@@ -782,7 +1229,7 @@ func WhatsAppSendContact(ctx context.Context, jid string, rjid string, contactNa
 		}
 
 		// Send WhatsApp Message Proto
-		_, err = WhatsAppClient[jid].SendMessage(ctx, remoteJID, msgContent, msgExtra)
+		err = WhatsAppSendMessage(ctx, jid, remoteJID, msgContent, msgExtra, msgOptions)
 		if err != nil {
 			return "", err
 		}
@@ -794,8 +1241,9 @@ func WhatsAppSendContact(ctx context.Context, jid string, rjid string, contactNa
 	return "", errors.New("WhatsApp Client is not Valid")
 }
 
-func WhatsAppSendLink(ctx context.Context, jid string, rjid string, linkCaption string, linkURL string) (string, error) {
-	if WhatsAppClient[jid] != nil {
+func WhatsAppSendLink(ctx context.Context, jid string, rjid string, linkCaption string, linkURL string, msgOptions SendOptions) (string, error) {
+	client := WhatsAppClient.Get(jid)
+	if client != nil {
 		var err error
 
 		// Make Sure WhatsApp Client is OK
@@ -830,10 +1278,10 @@ func WhatsAppSendLink(ctx context.Context, jid string, rjid string, linkCaption
 			ExtendedTextMessage: &waproto.ExtendedTextMessage{
 				Text:         proto.String(msgText),
 				MatchedText:  proto.String(msgCaption),
-				CanonicalUrl: proto.String(linkURL),
+				CanonicalURL: proto.String(linkURL),
 				ContextInfo: &waproto.ContextInfo{
 					ActionLink: &waproto.ActionLink{
-						Url:         proto.String(linkURL),
+						URL:         proto.String(linkURL),
 						ButtonTitle: proto.String(msgCaption),
 					},
 				},
@@ -841,7 +1289,7 @@ func WhatsAppSendLink(ctx context.Context, jid string, rjid string, linkCaption
 		}
 
 		// Send WhatsApp Message Proto
-		_, err = WhatsAppClient[jid].SendMessage(ctx, remoteJID, msgContent, msgExtra)
+		err = WhatsAppSendMessage(ctx, jid, remoteJID, msgContent, msgExtra, msgOptions)
 		if err != nil {
 			return "", err
 		}
@@ -853,8 +1301,9 @@ func WhatsAppSendLink(ctx context.Context, jid string, rjid string, linkCaption
 	return "", errors.New("WhatsApp Client is not Valid")
 }
 
-func WhatsAppSendSticker(ctx context.Context, jid string, rjid string, stickerBytes []byte) (string, error) {
-	if WhatsAppClient[jid] != nil {
+func WhatsAppSendSticker(ctx context.Context, jid string, rjid string, stickerBytes []byte, stickerPackName string, stickerPackPublisher string, msgOptions SendOptions) (string, error) {
+	client := WhatsAppClient.Get(jid)
+	if client != nil {
 		var err error
 
 		// Make Sure WhatsApp Client is OK
@@ -869,27 +1318,51 @@ func WhatsAppSendSticker(ctx context.Context, jid string, rjid string, stickerBy
 			return "", errors.New("WhatsApp Personal ID is Not Registered")
 		}
 
+		// Resolve Send Options Before Processing Media
+		// So Invalid Reply or Mention Does Not Waste Upload
+		msgOptions, err = WhatsAppResolveSendOptions(jid, remoteJID, "", msgOptions)
+		if err != nil {
+			return "", err
+		}
+
 		// Set Chat Presence
 		WhatsAppComposeStatus(jid, remoteJID, true, false)
 		defer WhatsAppComposeStatus(jid, remoteJID, false, false)
 
-		stickerConvDecode, err := imgconv.Decode(bytes.NewReader(stickerBytes))
+		// Convert Static Sticker to Padded 512x512 px WebP
+		// Or Validate Animated WebP Sticker
+		sticker, err := WhatsAppStickerConvert(stickerBytes)
 		if err != nil {
-			return "", errors.New("Error While Decoding Convert Sticker Stream")
+			return "", err
 		}
 
-		stickerConvResize := imgconv.Resize(stickerConvDecode, imgconv.ResizeOption{Width: 512, Height: 512})
-		stickerConvEncode := new(bytes.Buffer)
+		// Use Default Sticker Pack Metadata When Not Provided
+		if len(stickerPackName) == 0 {
+			stickerPackName = WhatsAppStickerPackName
+		}
 
-		err = webp.Encode(stickerConvEncode, stickerConvResize)
-		if err != nil {
-			return "", errors.New("Error While Encoding Convert Sticker Stream")
+		if len(stickerPackPublisher) == 0 {
+			stickerPackPublisher = WhatsAppStickerPackPublisher
 		}
 
-		stickerBytes = stickerConvEncode.Bytes()
+		// Embed Sticker Pack Metadata as EXIF
+		// So Recipients See Sticker Pack Name and Publisher When Saving Sticker
+		if len(stickerPackName) > 0 || len(stickerPackPublisher) > 0 {
+			stickerExif, err := WhatsAppStickerPackExif(stickerPackName, stickerPackPublisher)
+			if err != nil {
+				return "", err
+			}
+
+			sticker, err = WhatsAppStickerSetExif(sticker, stickerExif)
+			if err != nil {
+				return "", err
+			}
+		}
+
+		stickerBytes = sticker.Bytes
 
 		// Upload Image to WhatsApp Storage Server
-		stickerUploaded, err := WhatsAppClient[jid].Upload(ctx, stickerBytes, whatsmeow.MediaImage)
+		stickerUploaded, err := client.Upload(ctx, stickerBytes, whatsmeow.MediaImage)
 		if err != nil {
 			return "", errors.New("Error While Uploading Media to WhatsApp Server")
 		}
@@ -900,18 +1373,115 @@ func WhatsAppSendSticker(ctx context.Context, jid string, rjid string, stickerBy
 		}
 		msgContent := &waproto.Message{
 			StickerMessage: &waproto.StickerMessage{
-				Url:           proto.String(stickerUploaded.URL),
+				URL:           proto.String(stickerUploaded.URL),
 				DirectPath:    proto.String(stickerUploaded.DirectPath),
 				Mimetype:      proto.String("image/webp"),
 				FileLength:    proto.Uint64(stickerUploaded.FileLength),
-				FileSha256:    stickerUploaded.FileSHA256,
-				FileEncSha256: stickerUploaded.FileEncSHA256,
+				FileSHA256:    stickerUploaded.FileSHA256,
+				FileEncSHA256: stickerUploaded.FileEncSHA256,
 				MediaKey:      stickerUploaded.MediaKey,
+				Width:         proto.Uint32(sticker.Width),
+				Height:        proto.Uint32(sticker.Height),
+				IsAnimated:    proto.Bool(sticker.IsAnimated),
 			},
 		}
 
 		// Send WhatsApp Message Proto
-		_, err = WhatsAppClient[jid].SendMessage(ctx, remoteJID, msgContent, msgExtra)
+		err = WhatsAppSendMessage(ctx, jid, remoteJID, msgContent, msgExtra, msgOptions)
+		if err != nil {
+			return "", err
+		}
+
+		// Cache Sent Media to Media Store
+		WhatsAppMediaCache(jid, msgExtra.ID, stickerBytes, msgContent.GetStickerMessage().GetMimetype(), "")
+
+		return msgExtra.ID, nil
+	}
+
+	// Return Error WhatsApp Client is not Valid
+	return "", errors.New("WhatsApp Client is not Valid")
+}
+
+func WhatsAppSendPoll(ctx context.Context, jid string, rjid string, pollQuestion string, pollOptions []string, pollSelectableCount int, msgOptions SendOptions) (string, error) {
+	client := WhatsAppClient.Get(jid)
+	if client != nil {
+		var err error
+
+		// Make Sure WhatsApp Client is OK
+		err = WhatsAppIsClientOK(jid)
+		if err != nil {
+			return "", err
+		}
+
+		// Make Sure Poll Question and Options are Valid
+		err = WhatsAppPollValidate(pollQuestion, pollOptions, pollSelectableCount)
+		if err != nil {
+			return "", err
+		}
+
+		// Compose New Remote JID
+		remoteJID := WhatsAppComposeJID(rjid)
+		if WhatsAppGetJID(jid, remoteJID.String()).IsEmpty() {
+			return "", errors.New("WhatsApp Personal ID is Not Registered")
+		}
+
+		// Set Chat Presence
+		WhatsAppComposeStatus(jid, remoteJID, true, false)
+		defer WhatsAppComposeStatus(jid, remoteJID, false, false)
+
+		// Compose WhatsApp Proto
+		// Poll Message Secret is Stored by Client When Sent
+		// So Incoming Votes Can be Decrypted Later
+		msgExtra := whatsmeow.SendRequestExtra{
+			ID: whatsmeow.GenerateMessageID(),
+		}
+		msgContent := client.BuildPollCreation(pollQuestion, pollOptions, pollSelectableCount)
+
+		// Send WhatsApp Message Proto
+		err = WhatsAppSendMessage(ctx, jid, remoteJID, msgContent, msgExtra, msgOptions)
+		if err != nil {
+			return "", err
+		}
+
+		return msgExtra.ID, nil
+	}
+
+	// Return Error WhatsApp Client is not Valid
+	return "", errors.New("WhatsApp Client is not Valid")
+}
+
+func WhatsAppSendReaction(ctx context.Context, jid string, rjid string, msgID string, msgSender string, reaction string) (string, error) {
+	client := WhatsAppClient.Get(jid)
+	if client != nil {
+		var err error
+
+		// Make Sure WhatsApp Client is OK
+		err = WhatsAppIsClientOK(jid)
+		if err != nil {
+			return "", err
+		}
+
+		// Compose New Remote JID
+		remoteJID := WhatsAppComposeJID(rjid)
+		if WhatsAppGetJID(jid, remoteJID.String()).IsEmpty() {
+			return "", errors.New("WhatsApp Personal ID is Not Registered")
+		}
+
+		// Get Sender of Reacted Message
+		senderJID, err := WhatsAppMessageSender(jid, remoteJID, msgID, msgSender)
+		if err != nil {
+			return "", err
+		}
+
+		// Compose WhatsApp Proto
+		// Empty Reaction Removes Previous Reaction
+		msgExtra := whatsmeow.SendRequestExtra{
+			ID: whatsmeow.GenerateMessageID(),
+		}
+		msgContent := client.BuildReaction(remoteJID, senderJID, msgID, reaction)
+
+		// Send WhatsApp Message Proto
+		err = WhatsAppSendMessage(ctx, jid, remoteJID, msgContent, msgExtra, SendOptions{})
 		if err != nil {
 			return "", err
 		}
@@ -923,8 +1493,154 @@ func WhatsAppSendSticker(ctx context.Context, jid string, rjid string, stickerBy
 	return "", errors.New("WhatsApp Client is not Valid")
 }
 
+func WhatsAppRevokeMessage(ctx context.Context, jid string, rjid string, msgID string, msgSender string) error {
+	client := WhatsAppClient.Get(jid)
+	if client != nil {
+		var err error
+
+		// Make Sure WhatsApp Client is OK
+		err = WhatsAppIsClientOK(jid)
+		if err != nil {
+			return err
+		}
+
+		// Compose New Remote JID
+		remoteJID := WhatsAppComposeJID(rjid)
+		if WhatsAppGetJID(jid, remoteJID.String()).IsEmpty() {
+			return errors.New("WhatsApp Personal ID is Not Registered")
+		}
+
+		// Get Sender of Revoked Message
+		// Default to Our Own Message When Not Archived
+		ownJID := client.Store.ID.ToNonAD()
+		senderJID := ownJID
+
+		if len(msgSender) > 0 {
+			senderJID = WhatsAppComposeJID(msgSender)
+		} else {
+			archived, _, err := WhatsAppMessageGet(jid, msgID)
+			if err == nil && len(archived.SenderJID) > 0 {
+				senderJID, err = types.ParseJID(archived.SenderJID)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		// Message Sent by Others Can Only be Revoked by Group Admin
+		if senderJID.User != ownJID.User {
+			if remoteJID.Server != types.GroupServer {
+				return errors.New("WhatsApp Message Sent by Others Can Only be Revoked in Group")
+			}
+
+			groupInfo, err := client.GetGroupInfo(remoteJID)
+			if err != nil {
+				return err
+			}
+
+			isAdmin := false
+			for _, participant := range groupInfo.Participants {
+				if participant.JID.User == ownJID.User {
+					isAdmin = participant.IsAdmin || participant.IsSuperAdmin
+					break
+				}
+			}
+
+			if !isAdmin {
+				return errors.New("WhatsApp Message Sent by Others Can Only be Revoked by Group Admin")
+			}
+		}
+
+		// Compose WhatsApp Proto
+		msgExtra := whatsmeow.SendRequestExtra{
+			ID: whatsmeow.GenerateMessageID(),
+		}
+		msgContent := client.BuildRevoke(remoteJID, senderJID, msgID)
+
+		// Send WhatsApp Message Proto
+		return WhatsAppSendMessage(ctx, jid, remoteJID, msgContent, msgExtra, SendOptions{})
+	}
+
+	// Return Error WhatsApp Client is not Valid
+	return errors.New("WhatsApp Client is not Valid")
+}
+
+var (
+	ErrMessageNotOutgoing = errors.New("WhatsApp Message Can Only be Edited for Sent Message")
+	ErrMessageNotText     = errors.New("WhatsApp Message Can Only be Edited for Text Message")
+	ErrMessageEditExpired = errors.New("WhatsApp Message Edit Window is Expired")
+)
+
+func WhatsAppEditMessage(ctx context.Context, jid string, msgID string, message string) (ArchivedMessage, error) {
+	client := WhatsAppClient.Get(jid)
+	if client != nil {
+		var err error
+
+		// Make Sure WhatsApp Client is OK
+		err = WhatsAppIsClientOK(jid)
+		if err != nil {
+			return ArchivedMessage{}, err
+		}
+
+		// Get Edited Message from Message Archive
+		archived, _, err := WhatsAppMessageGet(jid, msgID)
+		if err != nil {
+			return ArchivedMessage{}, err
+		}
+
+		// Only Text Message Sent by This Device Within Edit Window Can be Edited
+		if archived.Direction != MessageDirectionOutgoing {
+			return ArchivedMessage{}, ErrMessageNotOutgoing
+		}
+
+		if archived.Type != "text" {
+			return ArchivedMessage{}, ErrMessageNotText
+		}
+
+		if time.Since(time.Unix(archived.Timestamp, 0)) > whatsmeow.EditWindow {
+			return ArchivedMessage{}, ErrMessageEditExpired
+		}
+
+		remoteJID, err := types.ParseJID(archived.ChatJID)
+		if err != nil {
+			return ArchivedMessage{}, err
+		}
+
+		// Compose WhatsApp Proto
+		msgExtra := whatsmeow.SendRequestExtra{
+			ID: whatsmeow.GenerateMessageID(),
+		}
+		msgContent := client.BuildEdit(remoteJID, msgID, &waproto.Message{
+			Conversation: proto.String(message),
+		})
+
+		// Send WhatsApp Message Proto
+		// Edit History is Recorded When Sent Message is Archived
+		err = WhatsAppSendMessage(ctx, jid, remoteJID, msgContent, msgExtra, SendOptions{})
+		if err != nil {
+			return ArchivedMessage{}, err
+		}
+
+		archived, _, err = WhatsAppMessageGet(jid, msgID)
+		if err != nil {
+			return ArchivedMessage{}, err
+		}
+
+		archived.Edits, err = WhatsAppMessageGetEdits(jid, msgID)
+		if err != nil {
+			return ArchivedMessage{}, err
+		}
+
+		return archived, nil
+	}
+
+	// Return Error WhatsApp Client is not Valid
+	return ArchivedMessage{}, errors.New("WhatsApp Client is not Valid")
+}
+
 func WhatsAppGetGroup(jid string) ([]types.GroupInfo, error) {
-	if WhatsAppClient[jid] != nil {
+	client := WhatsAppClient.Get(jid)
+	if client != nil {
 		var err error
 
 		// Make Sure WhatsApp Client is OK
@@ -934,7 +1650,7 @@ func WhatsAppGetGroup(jid string) ([]types.GroupInfo, error) {
 		}
 
 		// Get Joined Group List
-		groups, err := WhatsAppClient[jid].GetJoinedGroups()
+		groups, err := client.GetJoinedGroups()
 		if err != nil {
 			return nil, err
 		}